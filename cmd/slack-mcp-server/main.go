@@ -40,6 +40,62 @@ func main() {
 		)
 	}
 
+	err = validateToolConfig(os.Getenv("SLACK_MCP_UPLOAD_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_UPLOAD_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	err = validateToolConfig(os.Getenv("SLACK_MCP_PINS_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_PINS_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	err = validateToolConfig(os.Getenv("SLACK_MCP_BOOKMARKS_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_BOOKMARKS_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	err = validateToolConfig(os.Getenv("SLACK_MCP_SAVED_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_SAVED_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	err = validateToolConfig(os.Getenv("SLACK_MCP_CHANNELS_MANAGE_TOOL"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_CHANNELS_MANAGE_TOOL",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
+	if config := os.Getenv("SLACK_MCP_SET_STATUS_TOOL"); config != "" && config != "true" && config != "1" {
+		logger.Fatal("error in SLACK_MCP_SET_STATUS_TOOL",
+			zap.String("context", "console"),
+			zap.String("config", config),
+			zap.String("allowed", "true, 1"),
+		)
+	}
+
+	if format := strings.ToLower(os.Getenv("SLACK_MCP_OUTPUT_FORMAT")); format != "" && format != "csv" && format != "json" {
+		logger.Fatal("error in SLACK_MCP_OUTPUT_FORMAT",
+			zap.String("context", "console"),
+			zap.String("format", format),
+			zap.String("allowed", "csv, json"),
+		)
+	}
+
 	p := provider.New(transport, logger)
 	s := server.NewMCPServer(p, logger)
 
@@ -154,6 +210,14 @@ func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logge
 			)
 		}
 
+		// User groups are optional, e.g. the token may lack usergroups:read scope
+		if err := p.RefreshUsergroups(context.Background()); err != nil {
+			logger.Warn("Failed to cache user groups, usergroups tools will retry on demand",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
+
 		ready, _ := p.IsReady()
 		if ready {
 			once.Do(func() {
@@ -194,6 +258,15 @@ func newChannelsWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Lo
 				)
 			})
 		}
+
+		// Archived channels are only needed to resolve old names, so they
+		// are cached after the server is reported ready
+		if err := p.RefreshArchivedChannels(context.Background()); err != nil {
+			logger.Warn("Failed to cache archived channels, they can still be referenced by ID",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
 	}
 }
 
@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	contentTypeBlocks      = "application/vnd.slack.blocks+json"
+	contentTypeAttachments = "application/vnd.slack.attachments+json"
+
+	maxMessageBlocks        = 50
+	maxMessageAttachments   = 100
+	maxBlockIDLength        = 255
+	maxSectionTextLength    = 3000
+	maxSectionFields        = 10
+	maxSectionFieldLength   = 2000
+	maxHeaderTextLength     = 150
+	maxMarkdownBlockLength  = 12000
+	maxContextElements      = 10
+	maxActionsElements      = 25
+	maxImageAltTextLength   = 2000
+	maxImageURLLength       = 3000
+	maxButtonTextLength     = 75
+	maxActionIDLength       = 255
+	maxButtonValueLength    = 2000
+	maxBlockErrorsToReport  = 10
+	maxFallbackTextLength   = 3000
+	maxAttachmentTextLength = 8000
+)
+
+// blockElementTypes are the interactive and display elements accepted as
+// section accessories and in actions blocks
+var blockElementTypes = map[string]struct{}{
+	"button":                     {},
+	"checkboxes":                 {},
+	"datepicker":                 {},
+	"datetimepicker":             {},
+	"image":                      {},
+	"multi_static_select":        {},
+	"multi_external_select":      {},
+	"multi_users_select":         {},
+	"multi_conversations_select": {},
+	"multi_channels_select":      {},
+	"overflow":                   {},
+	"radio_buttons":              {},
+	"static_select":              {},
+	"external_select":            {},
+	"users_select":               {},
+	"conversations_select":       {},
+	"channels_select":            {},
+	"timepicker":                 {},
+	"workflow_button":            {},
+}
+
+// messageContentTypes lists the accepted content_type values of message tools
+var messageContentTypes = []string{"text/markdown", "text/plain", contentTypeBlocks, contentTypeAttachments}
+
+func validateContentType(contentType string) error {
+	for _, ct := range messageContentTypes {
+		if ct == contentType {
+			return nil
+		}
+	}
+	return fmt.Errorf("content_type must be one of '%s'", strings.Join(messageContentTypes, "', '"))
+}
+
+// blockPayload is the object form of a Block Kit payload, a bare JSON array
+// of blocks is accepted as well
+type blockPayload struct {
+	Text   string            `json:"text"`
+	Blocks []json.RawMessage `json:"blocks"`
+}
+
+// attachmentPayload is the object form of a legacy attachments payload, a
+// bare JSON array of attachments is accepted as well
+type attachmentPayload struct {
+	Text        string            `json:"text"`
+	Attachments []json.RawMessage `json:"attachments"`
+}
+
+// parseBlocksPayload validates a Block Kit payload and returns its blocks and
+// the notification text, which falls back to the text of the blocks
+func parseBlocksPayload(payload string) (slack.Blocks, string, error) {
+	var p blockPayload
+	trimmed := strings.TrimSpace(payload)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &p.Blocks); err != nil {
+			return slack.Blocks{}, "", fmt.Errorf("invalid Block Kit payload: %v", err)
+		}
+	} else if err := json.Unmarshal([]byte(trimmed), &p); err != nil {
+		return slack.Blocks{}, "", fmt.Errorf("invalid Block Kit payload, expected a JSON array of blocks or an object with 'blocks': %v", err)
+	}
+
+	if len(p.Blocks) == 0 {
+		return slack.Blocks{}, "", errors.New("invalid Block Kit payload: at least one block is required")
+	}
+	if len(p.Blocks) > maxMessageBlocks {
+		return slack.Blocks{}, "", fmt.Errorf("invalid Block Kit payload: a message can have at most %d blocks, got %d", maxMessageBlocks, len(p.Blocks))
+	}
+
+	v := &blockValidator{blockIDs: make(map[string]struct{})}
+	for i, raw := range p.Blocks {
+		v.validateBlock(fmt.Sprintf("blocks[%d]", i), raw)
+	}
+	if err := v.err(); err != nil {
+		return slack.Blocks{}, "", err
+	}
+
+	var blocks slack.Blocks
+	data, _ := json.Marshal(p.Blocks)
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return slack.Blocks{}, "", fmt.Errorf("invalid Block Kit payload: %v", err)
+	}
+
+	fallback := p.Text
+	if fallback == "" {
+		fallback = blocksFallbackText(blocks)
+	}
+	if len([]rune(fallback)) > maxFallbackTextLength {
+		fallback = string([]rune(fallback)[:maxFallbackTextLength])
+	}
+	return blocks, fallback, nil
+}
+
+// parseAttachmentsPayload validates a legacy attachments payload and returns
+// the attachments and the message text
+func parseAttachmentsPayload(payload string) ([]slack.Attachment, string, error) {
+	var p attachmentPayload
+	trimmed := strings.TrimSpace(payload)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &p.Attachments); err != nil {
+			return nil, "", fmt.Errorf("invalid attachments payload: %v", err)
+		}
+	} else if err := json.Unmarshal([]byte(trimmed), &p); err != nil {
+		return nil, "", fmt.Errorf("invalid attachments payload, expected a JSON array of attachments or an object with 'attachments': %v", err)
+	}
+
+	if len(p.Attachments) == 0 {
+		return nil, "", errors.New("invalid attachments payload: at least one attachment is required")
+	}
+	if len(p.Attachments) > maxMessageAttachments {
+		return nil, "", fmt.Errorf("invalid attachments payload: a message can have at most %d attachments, got %d", maxMessageAttachments, len(p.Attachments))
+	}
+
+	v := &blockValidator{blockIDs: make(map[string]struct{})}
+	for i, raw := range p.Attachments {
+		path := fmt.Sprintf("attachments[%d]", i)
+		var a map[string]any
+		if err := json.Unmarshal(raw, &a); err != nil {
+			v.addf(path, "must be an object")
+			continue
+		}
+
+		hasContent := false
+		for _, key := range []string{"text", "fallback", "pretext", "title", "fields", "blocks", "image_url"} {
+			if _, ok := a[key]; ok {
+				hasContent = true
+				break
+			}
+		}
+		if !hasContent {
+			v.addf(path, "must have at least one of text, fallback, pretext, title, fields, blocks or image_url")
+		}
+		if s, ok := a["text"].(string); ok && len([]rune(s)) > maxAttachmentTextLength {
+			v.addf(path+".text", "must be at most %d characters", maxAttachmentTextLength)
+		}
+		if blocks, ok := a["blocks"].([]any); ok {
+			for j, b := range blocks {
+				data, _ := json.Marshal(b)
+				v.validateBlock(fmt.Sprintf("%s.blocks[%d]", path, j), data)
+			}
+		}
+	}
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	var attachments []slack.Attachment
+	data, _ := json.Marshal(p.Attachments)
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return nil, "", fmt.Errorf("invalid attachments payload: %v", err)
+	}
+	return attachments, p.Text, nil
+}
+
+type blockValidator struct {
+	errs     []string
+	blockIDs map[string]struct{}
+}
+
+func (v *blockValidator) addf(path, format string, args ...any) {
+	v.errs = append(v.errs, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *blockValidator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	errs := v.errs
+	more := ""
+	if len(errs) > maxBlockErrorsToReport {
+		more = fmt.Sprintf("; and %d more", len(errs)-maxBlockErrorsToReport)
+		errs = errs[:maxBlockErrorsToReport]
+	}
+	return fmt.Errorf("invalid Block Kit payload: %s%s", strings.Join(errs, "; "), more)
+}
+
+func (v *blockValidator) validateBlock(path string, raw json.RawMessage) {
+	var b map[string]any
+	if err := json.Unmarshal(raw, &b); err != nil {
+		v.addf(path, "must be an object")
+		return
+	}
+
+	blockType, _ := b["type"].(string)
+	if id, ok := b["block_id"].(string); ok {
+		if len(id) > maxBlockIDLength {
+			v.addf(path+".block_id", "must be at most %d characters", maxBlockIDLength)
+		}
+		if _, dup := v.blockIDs[id]; dup {
+			v.addf(path+".block_id", "%q is not unique", id)
+		}
+		v.blockIDs[id] = struct{}{}
+	}
+
+	switch blockType {
+	case "section":
+		_, hasText := b["text"]
+		fields, hasFields := b["fields"].([]any)
+		if !hasText && !hasFields {
+			v.addf(path, "section requires text or fields")
+		}
+		if hasText {
+			v.validateText(path+".text", b["text"], maxSectionTextLength, false)
+		}
+		if hasFields {
+			if len(fields) > maxSectionFields {
+				v.addf(path+".fields", "must have at most %d items", maxSectionFields)
+			}
+			for i, f := range fields {
+				v.validateText(fmt.Sprintf("%s.fields[%d]", path, i), f, maxSectionFieldLength, false)
+			}
+		}
+		if acc, ok := b["accessory"]; ok {
+			v.validateElement(path+".accessory", acc)
+		}
+	case "header":
+		v.validateText(path+".text", b["text"], maxHeaderTextLength, true)
+	case "divider":
+	case "image":
+		v.validateImage(path, b)
+		if title, ok := b["title"]; ok {
+			v.validateText(path+".title", title, maxImageAltTextLength, true)
+		}
+	case "context":
+		elements, _ := b["elements"].([]any)
+		if len(elements) == 0 || len(elements) > maxContextElements {
+			v.addf(path+".elements", "must have between 1 and %d items", maxContextElements)
+		}
+		for i, e := range elements {
+			elemPath := fmt.Sprintf("%s.elements[%d]", path, i)
+			m, _ := e.(map[string]any)
+			switch m["type"] {
+			case "image":
+				v.validateImage(elemPath, m)
+			case "plain_text", "mrkdwn":
+				v.validateText(elemPath, e, maxSectionTextLength, false)
+			default:
+				v.addf(elemPath+".type", "must be image, plain_text or mrkdwn, got %q", m["type"])
+			}
+		}
+	case "actions":
+		elements, _ := b["elements"].([]any)
+		if len(elements) == 0 || len(elements) > maxActionsElements {
+			v.addf(path+".elements", "must have between 1 and %d items", maxActionsElements)
+		}
+		for i, e := range elements {
+			v.validateElement(fmt.Sprintf("%s.elements[%d]", path, i), e)
+		}
+	case "markdown":
+		text, _ := b["text"].(string)
+		if text == "" {
+			v.addf(path+".text", "is required")
+		} else if len([]rune(text)) > maxMarkdownBlockLength {
+			v.addf(path+".text", "must be at most %d characters", maxMarkdownBlockLength)
+		}
+	case "rich_text":
+		if elements, _ := b["elements"].([]any); len(elements) == 0 {
+			v.addf(path+".elements", "is required")
+		}
+	case "video", "file", "input":
+		// Passed to Slack as is, they are rarely posted by hand
+	case "":
+		v.addf(path+".type", "is required")
+	default:
+		v.addf(path+".type", "unknown block type %q, expected one of section, header, divider, image, context, actions, markdown, rich_text, video, file, input", blockType)
+	}
+}
+
+func (v *blockValidator) validateText(path string, value any, maxLength int, plainOnly bool) {
+	t, ok := value.(map[string]any)
+	if !ok {
+		v.addf(path, "must be a text object like {\"type\": \"mrkdwn\", \"text\": \"...\"}")
+		return
+	}
+	switch t["type"] {
+	case "plain_text":
+	case "mrkdwn":
+		if plainOnly {
+			v.addf(path+".type", "must be plain_text")
+		}
+	default:
+		v.addf(path+".type", "must be plain_text or mrkdwn, got %q", t["type"])
+	}
+	text, _ := t["text"].(string)
+	if text == "" {
+		v.addf(path+".text", "is required")
+	} else if len([]rune(text)) > maxLength {
+		v.addf(path+".text", "must be at most %d characters, got %d", maxLength, len([]rune(text)))
+	}
+}
+
+func (v *blockValidator) validateImage(path string, m map[string]any) {
+	url, _ := m["image_url"].(string)
+	_, hasFile := m["slack_file"]
+	if url == "" && !hasFile {
+		v.addf(path, "image requires image_url or slack_file")
+	}
+	if len(url) > maxImageURLLength {
+		v.addf(path+".image_url", "must be at most %d characters", maxImageURLLength)
+	}
+	alt, _ := m["alt_text"].(string)
+	if alt == "" {
+		v.addf(path+".alt_text", "is required")
+	} else if len([]rune(alt)) > maxImageAltTextLength {
+		v.addf(path+".alt_text", "must be at most %d characters", maxImageAltTextLength)
+	}
+}
+
+func (v *blockValidator) validateElement(path string, value any) {
+	e, ok := value.(map[string]any)
+	if !ok {
+		v.addf(path, "must be an object")
+		return
+	}
+	elementType, _ := e["type"].(string)
+	if _, known := blockElementTypes[elementType]; !known {
+		v.addf(path+".type", "unknown element type %q", elementType)
+		return
+	}
+	if id, ok := e["action_id"].(string); ok && len(id) > maxActionIDLength {
+		v.addf(path+".action_id", "must be at most %d characters", maxActionIDLength)
+	}
+
+	switch elementType {
+	case "button":
+		v.validateText(path+".text", e["text"], maxButtonTextLength, true)
+		if value, ok := e["value"].(string); ok && len(value) > maxButtonValueLength {
+			v.addf(path+".value", "must be at most %d characters", maxButtonValueLength)
+		}
+		if style, ok := e["style"].(string); ok && style != "primary" && style != "danger" {
+			v.addf(path+".style", "must be primary or danger, got %q", style)
+		}
+	case "image":
+		v.validateImage(path, e)
+	}
+}
+
+// blocksFallbackText joins the texts of header, section, context and markdown
+// blocks, Slack shows it in notifications
+func blocksFallbackText(blocks slack.Blocks) string {
+	var parts []string
+	for _, block := range blocks.BlockSet {
+		switch b := block.(type) {
+		case *slack.HeaderBlock:
+			if b.Text != nil && b.Text.Text != "" {
+				parts = append(parts, b.Text.Text)
+			}
+		case *slack.SectionBlock:
+			if b.Text != nil && b.Text.Text != "" {
+				parts = append(parts, b.Text.Text)
+			}
+			for _, f := range b.Fields {
+				parts = append(parts, f.Text)
+			}
+		case *slack.ContextBlock:
+			for _, e := range b.ContextElements.Elements {
+				if obj, ok := e.(*slack.TextBlockObject); ok && obj.Text != "" {
+					parts = append(parts, obj.Text)
+				}
+			}
+		case *slack.MarkdownBlock:
+			if b.Text != "" {
+				parts = append(parts, b.Text)
+			}
+		}
+	}
+	return strings.Join(parts, "\n")
+}
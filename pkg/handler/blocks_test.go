@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseBlocksPayload(t *testing.T) {
+	payload := `[
+		{"type": "header", "text": {"type": "plain_text", "text": "Deploy finished"}},
+		{"type": "section", "text": {"type": "mrkdwn", "text": "*api* is live"},
+		 "accessory": {"type": "button", "text": {"type": "plain_text", "text": "Open"}, "url": "https://example.com", "style": "primary"}},
+		{"type": "section", "fields": [{"type": "mrkdwn", "text": "*Env*\nprod"}, {"type": "mrkdwn", "text": "*Version*\n1.2.3"}]},
+		{"type": "divider"},
+		{"type": "context", "elements": [{"type": "mrkdwn", "text": "by @deploybot"}]},
+		{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Rollback"}, "style": "danger", "action_id": "rollback"}]},
+		{"type": "markdown", "text": "**All checks passed**"}
+	]`
+
+	blocks, fallback, err := parseBlocksPayload(payload)
+	require.NoError(t, err)
+	assert.Len(t, blocks.BlockSet, 7)
+	assert.Equal(t, "Deploy finished\n*api* is live\n*Env*\nprod\n*Version*\n1.2.3\nby @deploybot\n**All checks passed**", fallback)
+
+	_, fallback, err = parseBlocksPayload(`[{"type": "markdown", "text": "**Only markdown**"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, "**Only markdown**", fallback)
+
+	_, fallback, err = parseBlocksPayload(`[
+		{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Approve"}}]},
+		{"type": "context", "elements": [{"type": "image", "image_url": "https://example.com/a.png", "alt_text": "logo"}, {"type": "plain_text", "text": "Requested by Alice"}]}
+	]`)
+	require.NoError(t, err)
+	assert.Equal(t, "Requested by Alice", fallback)
+
+	_, fallback, err = parseBlocksPayload(`{"text": "Deploy finished", "blocks": [{"type": "divider"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Deploy finished", fallback)
+}
+
+func TestUnitParseBlocksPayload_Invalid(t *testing.T) {
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(`{"type": "divider"},`, maxMessageBlocks+1), ",") + "]"
+	longText := strings.Repeat("a", maxSectionTextLength+1)
+
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{"not json", `# Hello`, "invalid Block Kit payload"},
+		{"empty", `[]`, "at least one block"},
+		{"too many blocks", tooMany, fmt.Sprintf("at most %d blocks", maxMessageBlocks)},
+		{"unknown block", `[{"type": "table"}]`, `blocks[0].type: unknown block type "table"`},
+		{"long section", `[{"type": "section", "text": {"type": "mrkdwn", "text": "` + longText + `"}}]`, "blocks[0].text.text: must be at most 3000 characters"},
+		{"empty section", `[{"type": "section"}]`, "section requires text or fields"},
+		{"mrkdwn header", `[{"type": "header", "text": {"type": "mrkdwn", "text": "Hi"}}]`, "blocks[0].text.type: must be plain_text"},
+		{"image without alt", `[{"type": "image", "image_url": "https://example.com/a.png"}]`, "blocks[0].alt_text: is required"},
+		{"unknown element", `[{"type": "actions", "elements": [{"type": "slider"}]}]`, `blocks[0].elements[0].type: unknown element type "slider"`},
+		{"bad button style", `[{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Go"}, "style": "green"}]}]`, "must be primary or danger"},
+		{"duplicate block id", `[{"type": "divider", "block_id": "a"}, {"type": "divider", "block_id": "a"}]`, `blocks[1].block_id: "a" is not unique`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseBlocksPayload(tt.payload)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUnitParseAttachmentsPayload(t *testing.T) {
+	attachments, msgText, err := parseAttachmentsPayload(`{"text": "Status", "attachments": [{"color": "#36a64f", "title": "Build", "text": "passed"}]}`)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "Status", msgText)
+	assert.Equal(t, "#36a64f", attachments[0].Color)
+
+	_, _, err = parseAttachmentsPayload(`[{"color": "#36a64f"}]`)
+	require.ErrorContains(t, err, "attachments[0]: must have at least one of")
+
+	_, _, err = parseAttachmentsPayload(`[{"blocks": [{"type": "header"}]}]`)
+	require.ErrorContains(t, err, "attachments[0].blocks[0].text")
+}
+
+func TestUnitValidateContentType(t *testing.T) {
+	for _, ct := range []string{"text/markdown", "text/plain", contentTypeBlocks, contentTypeAttachments} {
+		assert.NoError(t, validateContentType(ct))
+	}
+	assert.ErrorContains(t, validateContentType("text/html"), "content_type must be one of")
+}
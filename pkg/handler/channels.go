@@ -3,15 +3,20 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 
-	"github.com/gocarina/gocsv"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
 	"go.uber.org/zap"
 )
 
@@ -21,7 +26,52 @@ type Channel struct {
 	Topic       string `json:"topic"`
 	Purpose     string `json:"purpose"`
 	MemberCount int    `json:"memberCount"`
-	Cursor      string `json:"cursor"`
+	IsArchived  bool   `json:"isArchived"`
+	Cursor      string `json:"cursor,omitempty"`
+}
+
+type ChannelInfo struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Creator        string `json:"creator"`
+	Created        string `json:"created"`
+	Topic          string `json:"topic"`
+	TopicCreator   string `json:"topicCreator"`
+	TopicLastSet   string `json:"topicLastSet"`
+	Purpose        string `json:"purpose"`
+	PurposeCreator string `json:"purposeCreator"`
+	PurposeLastSet string `json:"purposeLastSet"`
+	PreviousNames  string `json:"previousNames"`
+	IsArchived     bool   `json:"isArchived"`
+	IsShared       bool   `json:"isShared"`
+	IsExtShared    bool   `json:"isExtShared"`
+	IsOrgShared    bool   `json:"isOrgShared"`
+	MemberCount    int    `json:"memberCount"`
+}
+
+const (
+	maxChannelNameLength    = 80
+	maxChannelTopicLength   = 250
+	maxChannelPurposeLength = 250
+	maxInviteUsers          = 1000
+)
+
+type channelCreateParams struct {
+	name      string
+	isPrivate bool
+	topic     string
+	purpose   string
+}
+
+type channelUsersParams struct {
+	channel string
+	users   []string
+}
+
+type channelTextParams struct {
+	channel string
+	text    string
 }
 
 type ChannelsHandler struct {
@@ -35,6 +85,7 @@ func NewChannelsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *
 	for _, v := range provider.AllChanTypes {
 		validTypes[v] = true
 	}
+	validTypes[provider.ArchivedChanType] = true
 
 	return &ChannelsHandler{
 		apiProvider: apiProvider,
@@ -87,19 +138,7 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 		})
 	}
 
-	csvBytes, err := gocsv.MarshalBytes(&channelList)
-	if err != nil {
-		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
-		return nil, err
-	}
-
-	return []mcp.ResourceContents{
-		mcp.TextResourceContents{
-			URI:      "slack://" + ws + "/channels",
-			MIMEType: "text/csv",
-			Text:     string(csvBytes),
-		},
-	}, nil
+	return marshalResource("slack://"+ws+"/channels", channelList)
 }
 
 func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -157,6 +196,12 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	)
 
 	allChannels := ch.apiProvider.ProvideChannelsMaps().Channels
+	if slices.Contains(channelTypes, provider.ArchivedChanType) {
+		if !ch.apiProvider.IsArchivedChannelsReady() {
+			ch.logger.Warn("Archived channels are still being cached, the list may be incomplete")
+		}
+		allChannels = mergeChannels(allChannels, ch.apiProvider.ProvideArchivedChannelsMaps().Channels)
+	}
 	ch.logger.Debug("Total channels available", zap.Int("count", len(allChannels)))
 
 	channels := filterChannelsByTypes(allChannels, channelTypes)
@@ -182,6 +227,7 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 			Topic:       channel.Topic,
 			Purpose:     channel.Purpose,
 			MemberCount: channel.MemberCount,
+			IsArchived:  channel.IsArchived,
 		})
 	}
 
@@ -200,13 +246,494 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 		ch.logger.Debug("Added cursor to last channel", zap.String("cursor", nextcur))
 	}
 
-	csvBytes, err := gocsv.MarshalBytes(&channelList)
+	return marshalStructuredRows(request, channelList)
+}
+
+// ChannelsInfoHandler returns metadata of a single channel and, optionally,
+// its members resolved to user names as a second CSV
+func (ch *ChannelsHandler) ChannelsInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsInfoHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	includeMembers := request.GetBool("include_members", true)
+
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		c, ok := ch.apiProvider.LookupChannel(channel)
+		if !ok {
+			return nil, channelNotFoundError(ch.apiProvider, channel)
+		}
+		channel = c.ID
+	}
+
+	info, err := ch.apiProvider.Slack().GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID:         channel,
+		IncludeNumMembers: true,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetConversationInfoContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	cached, ok := ch.apiProvider.ProvideChannelsMaps().Channels[info.ID]
+	if !ok {
+		cached = ch.apiProvider.ProvideArchivedChannelsMaps().Channels[info.ID]
+	}
+	channelInfo := toChannelInfo(info, cached, usersMap)
+
+	if !includeMembers {
+		return marshalRows(request, []ChannelInfo{channelInfo})
+	}
+
+	var (
+		memberIDs []string
+		cursor    string
+	)
+	for {
+		ids, nextcur, err := ch.apiProvider.Slack().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: info.ID,
+			Cursor:    cursor,
+			Limit:     1000,
+		})
+		if err != nil {
+			ch.logger.Error("Slack GetUsersInConversationContext failed", zap.String("channel", info.ID), zap.Error(err))
+			return nil, err
+		}
+		memberIDs = append(memberIDs, ids...)
+		if nextcur == "" {
+			break
+		}
+		cursor = nextcur
+	}
+
+	ch.logger.Debug("Fetched channel members", zap.String("channel", info.ID), zap.Int("count", len(memberIDs)))
+
+	members := make([]User, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		userName, realName, _ := getUserInfo(id, usersMap)
+		members = append(members, User{
+			UserID:   id,
+			UserName: userName,
+			RealName: realName,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserName < members[j].UserName
+	})
+
+	return marshalRows(request, []ChannelInfo{channelInfo}, relatedRows{name: "members", rows: members})
+}
+
+// ChannelsCreateHandler creates a public or private channel, sets its topic
+// and purpose if given and adds it to the channels cache
+func (ch *ChannelsHandler) ChannelsCreateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsCreateHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolCreate(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_create params", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.apiProvider.Slack().CreateConversationContext(ctx, slack.CreateConversationParams{
+		ChannelName: params.name,
+		IsPrivate:   params.isPrivate,
+	})
+	if err != nil {
+		ch.logger.Error("Slack CreateConversationContext failed", zap.String("name", params.name), zap.Error(err))
+		return nil, err
+	}
+
+	// Cache the channel right away, so it is resolvable by #name even if
+	// setting the topic or purpose fails below
+	ch.apiProvider.UpsertChannel(*channel)
+
+	if params.topic != "" {
+		updated, err := ch.apiProvider.Slack().SetTopicOfConversationContext(ctx, channel.ID, params.topic)
+		if err != nil {
+			ch.logger.Error("Slack SetTopicOfConversationContext failed", zap.String("channel", channel.ID), zap.Error(err))
+			return nil, fmt.Errorf("channel %s was created, but setting its topic failed: %w", channel.ID, err)
+		}
+		channel = updated
+	}
+	if params.purpose != "" {
+		updated, err := ch.apiProvider.Slack().SetPurposeOfConversationContext(ctx, channel.ID, params.purpose)
+		if err != nil {
+			ch.logger.Error("Slack SetPurposeOfConversationContext failed", zap.String("channel", channel.ID), zap.Error(err))
+			return nil, fmt.Errorf("channel %s was created, but setting its purpose failed: %w", channel.ID, err)
+		}
+		channel = updated
+	}
+
+	return ch.channelUpdatedResult(request, channel)
+}
+
+// ChannelsArchiveHandler archives a channel
+func (ch *ChannelsHandler) ChannelsArchiveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsArchiveHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.parseParamsToolManagedChannel(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_archive params", zap.Error(err))
+		return nil, err
+	}
+
+	if err := ch.apiProvider.Slack().ArchiveConversationContext(ctx, channel); err != nil {
+		ch.logger.Error("Slack ArchiveConversationContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	ch.apiProvider.SetChannelArchived(channel, true)
+
+	return marshalStatus(request, fmt.Sprintf("Successfully archived channel %s", channel))
+}
+
+// ChannelsUnarchiveHandler unarchives a channel
+func (ch *ChannelsHandler) ChannelsUnarchiveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsUnarchiveHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.parseParamsToolManagedChannel(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_unarchive params", zap.Error(err))
+		return nil, err
+	}
+
+	if err := ch.apiProvider.Slack().UnArchiveConversationContext(ctx, channel); err != nil {
+		ch.logger.Error("Slack UnArchiveConversationContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	ch.apiProvider.SetChannelArchived(channel, false)
+
+	return marshalStatus(request, fmt.Sprintf("Successfully unarchived channel %s", channel))
+}
+
+// ChannelsInviteHandler invites users to a channel and returns the updated
+// channel as CSV
+func (ch *ChannelsHandler) ChannelsInviteHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsInviteHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolChannelUsers(request, "users")
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_invite params", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.apiProvider.Slack().InviteUsersToConversationContext(ctx, params.channel, params.users...)
+	if err != nil {
+		ch.logger.Error("Slack InviteUsersToConversationContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, err
+	}
+
+	return ch.channelUpdatedResult(request, channel)
+}
+
+// ChannelsKickHandler removes a user from a channel
+func (ch *ChannelsHandler) ChannelsKickHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsKickHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolChannelUsers(request, "user")
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_kick params", zap.Error(err))
+		return nil, err
+	}
+	if len(params.users) != 1 {
+		return nil, errors.New("exactly one user must be provided")
+	}
+
+	if err := ch.apiProvider.Slack().KickUserFromConversationContext(ctx, params.channel, params.users[0]); err != nil {
+		ch.logger.Error("Slack KickUserFromConversationContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully removed user %s from channel %s", params.users[0], params.channel))
+}
+
+// ChannelsSetTopicHandler sets the topic of a channel and returns the updated
+// channel as CSV
+func (ch *ChannelsHandler) ChannelsSetTopicHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsSetTopicHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolChannelText(request, "topic", maxChannelTopicLength)
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_set_topic params", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.apiProvider.Slack().SetTopicOfConversationContext(ctx, params.channel, params.text)
+	if err != nil {
+		ch.logger.Error("Slack SetTopicOfConversationContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, err
+	}
+
+	return ch.channelUpdatedResult(request, channel)
+}
+
+// ChannelsSetPurposeHandler sets the purpose of a channel and returns the
+// updated channel as CSV
+func (ch *ChannelsHandler) ChannelsSetPurposeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsSetPurposeHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolChannelText(request, "purpose", maxChannelPurposeLength)
+	if err != nil {
+		ch.logger.Error("Failed to parse channels_set_purpose params", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.apiProvider.Slack().SetPurposeOfConversationContext(ctx, params.channel, params.text)
+	if err != nil {
+		ch.logger.Error("Slack SetPurposeOfConversationContext failed", zap.String("channel", params.channel), zap.Error(err))
+		return nil, err
+	}
+
+	return ch.channelUpdatedResult(request, channel)
+}
+
+// channelUpdatedResult refreshes the cached channel from an API response and
+// returns it
+func (ch *ChannelsHandler) channelUpdatedResult(request mcp.CallToolRequest, channel *slack.Channel) (*mcp.CallToolResult, error) {
+	cached := ch.apiProvider.UpsertChannel(*channel)
+	channelInfo := toChannelInfo(channel, cached, ch.apiProvider.ProvideUsersMap().Users)
+
+	return marshalRows(request, []ChannelInfo{channelInfo})
+}
+
+func (ch *ChannelsHandler) parseParamsToolCreate(request mcp.CallToolRequest) (*channelCreateParams, error) {
+	if _, err := ch.channelsManageConfig(); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(strings.TrimSpace(request.GetString("name", "")), "#")
+	if err := validateChannelName(name); err != nil {
+		return nil, err
+	}
+
+	topic := strings.TrimSpace(request.GetString("topic", ""))
+	if len([]rune(topic)) > maxChannelTopicLength {
+		return nil, fmt.Errorf("topic must be at most %d characters", maxChannelTopicLength)
+	}
+	purpose := strings.TrimSpace(request.GetString("purpose", ""))
+	if len([]rune(purpose)) > maxChannelPurposeLength {
+		return nil, fmt.Errorf("purpose must be at most %d characters", maxChannelPurposeLength)
+	}
+
+	return &channelCreateParams{
+		name:      name,
+		isPrivate: request.GetBool("is_private", false),
+		topic:     topic,
+		purpose:   purpose,
+	}, nil
+}
+
+func (ch *ChannelsHandler) parseParamsToolChannelUsers(request mcp.CallToolRequest, field string) (*channelUsersParams, error) {
+	channel, err := ch.parseParamsToolManagedChannel(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []string
+	for _, raw := range strings.Split(request.GetString(field, ""), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		user, err := lookupUser(ch.apiProvider, raw)
+		if err != nil {
+			ch.logger.Error("Failed to lookup user", zap.String("user", raw), zap.Error(err))
+			return nil, err
+		}
+		users = append(users, user.ID)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%s is required", field)
+	}
+	if len(users) > maxInviteUsers {
+		return nil, fmt.Errorf("at most %d users can be provided at once", maxInviteUsers)
+	}
+
+	return &channelUsersParams{
+		channel: channel,
+		users:   users,
+	}, nil
+}
+
+func (ch *ChannelsHandler) parseParamsToolChannelText(request mcp.CallToolRequest, field string, maxLength int) (*channelTextParams, error) {
+	channel, err := ch.parseParamsToolManagedChannel(request)
 	if err != nil {
-		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(csvBytes)), nil
+	// An empty value is allowed and clears the field
+	value := strings.TrimSpace(request.GetString(field, ""))
+	if len([]rune(value)) > maxLength {
+		return nil, fmt.Errorf("%s must be at most %d characters", field, maxLength)
+	}
+
+	return &channelTextParams{
+		channel: channel,
+		text:    value,
+	}, nil
+}
+
+// parseParamsToolManagedChannel resolves the channel_id of a channel
+// management tool and checks it against SLACK_MCP_CHANNELS_MANAGE_TOOL
+func (ch *ChannelsHandler) parseParamsToolManagedChannel(request mcp.CallToolRequest) (string, error) {
+	toolConfig, err := ch.channelsManageConfig()
+	if err != nil {
+		return "", err
+	}
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return "", errors.New("channel_id is required")
+	}
+	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+		c, ok := ch.apiProvider.LookupChannel(channel)
+		if !ok {
+			ch.logger.Error("Channel not found", zap.String("channel", channel))
+			return "", channelNotFoundError(ch.apiProvider, channel)
+		}
+		channel = c.ID
+	}
+
+	if !isChannelAllowedByPolicy(channel, toolConfig) {
+		ch.logger.Warn("Tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return "", fmt.Errorf("channel management tools are not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+	return channel, nil
+}
+
+func (ch *ChannelsHandler) channelsManageConfig() (string, error) {
+	toolConfig := os.Getenv("SLACK_MCP_CHANNELS_MANAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Channel management tools disabled by default", zap.String("env", "SLACK_MCP_CHANNELS_MANAGE_TOOL"))
+		return "", errors.New(
+			"by default, the channel management tools are disabled to guard Slack workspaces against accidental changes. " +
+				"To enable them, set the SLACK_MCP_CHANNELS_MANAGE_TOOL environment variable to true, 1, or comma separated list of channels " +
+				"to limit which channels the MCP can manage, e.g. 'SLACK_MCP_CHANNELS_MANAGE_TOOL=C1234567890,C0987654321', " +
+				"'SLACK_MCP_CHANNELS_MANAGE_TOOL=!C1234567890' to enable all except one or 'SLACK_MCP_CHANNELS_MANAGE_TOOL=true' for all channels",
+		)
+	}
+	return toolConfig, nil
+}
+
+// validateChannelName checks the naming rules of conversations.create upfront
+// to give a more helpful error than Slack's invalid_name_specials
+func validateChannelName(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if len([]rune(name)) > maxChannelNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxChannelNameLength)
+	}
+	for _, r := range name {
+		if unicode.IsUpper(r) || unicode.IsSpace(r) || r == '.' {
+			return fmt.Errorf("invalid channel name %q: it must be lowercase, without spaces or periods", name)
+		}
+	}
+	return nil
+}
+
+// toChannelInfo converts conversations.info response to a CSV row, preferring
+// the cached channel name as it already resolves DMs to @username
+func toChannelInfo(info *slack.Channel, cached provider.Channel, usersMap map[string]slack.User) ChannelInfo {
+	name := cached.Name
+	if name == "" {
+		name = "#" + info.Name
+	}
+
+	memberCount := info.NumMembers
+	if memberCount == 0 {
+		memberCount = cached.MemberCount
+	}
+
+	userName := func(id string) string {
+		if id == "" {
+			return ""
+		}
+		name, _, _ := getUserInfo(id, usersMap)
+		return name
+	}
+
+	return ChannelInfo{
+		ID:             info.ID,
+		Name:           name,
+		Type:           channelType(provider.Channel{IsIM: info.IsIM, IsMpIM: info.IsMpIM, IsPrivate: info.IsPrivate}),
+		Creator:        userName(info.Creator),
+		Created:        formatJSONTime(info.Created),
+		Topic:          info.Topic.Value,
+		TopicCreator:   userName(info.Topic.Creator),
+		TopicLastSet:   formatJSONTime(info.Topic.LastSet),
+		Purpose:        info.Purpose.Value,
+		PurposeCreator: userName(info.Purpose.Creator),
+		PurposeLastSet: formatJSONTime(info.Purpose.LastSet),
+		PreviousNames:  strings.Join(info.PreviousNames, ","),
+		IsArchived:     info.IsArchived,
+		IsShared:       info.IsShared,
+		IsExtShared:    info.IsExtShared,
+		IsOrgShared:    info.IsOrgShared,
+		MemberCount:    memberCount,
+	}
+}
+
+func formatJSONTime(t slack.JSONTime) string {
+	if t == 0 {
+		return ""
+	}
+	return t.Time().UTC().Format(time.RFC3339)
+}
+
+// mergeChannels returns a new map holding the channels of both maps
+func mergeChannels(active, archived map[string]provider.Channel) map[string]provider.Channel {
+	merged := make(map[string]provider.Channel, len(active)+len(archived))
+	for id, c := range active {
+		merged[id] = c
+	}
+	for id, c := range archived {
+		merged[id] = c
+	}
+	return merged
 }
 
 func filterChannelsByTypes(channels map[string]provider.Channel, types []string) []provider.Channel {
@@ -223,8 +750,16 @@ func filterChannelsByTypes(channels map[string]provider.Channel, types []string)
 	privateCount := 0
 	imCount := 0
 	mpimCount := 0
+	archivedCount := 0
 
 	for _, ch := range channels {
+		if ch.IsArchived {
+			if typeSet[provider.ArchivedChanType] {
+				result = append(result, ch)
+				archivedCount++
+			}
+			continue
+		}
 		if typeSet["public_channel"] && !ch.IsPrivate && !ch.IsIM && !ch.IsMpIM {
 			result = append(result, ch)
 			publicCount++
@@ -250,6 +785,7 @@ func filterChannelsByTypes(channels map[string]provider.Channel, types []string)
 		zap.Int("private_channels", privateCount),
 		zap.Int("ims", imCount),
 		zap.Int("mpims", mpimCount),
+		zap.Int("archived", archivedCount),
 	)
 
 	return result
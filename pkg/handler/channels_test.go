@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -161,3 +163,98 @@ func TestIntegrationPrivateChannelsList(t *testing.T) {
 
 	runChannelTest(t, env, "private_channel", expectedChannels)
 }
+
+func TestUnitToChannelInfo(t *testing.T) {
+	usersMap := map[string]slack.User{
+		"U1": {ID: "U1", Name: "alice", RealName: "Alice"},
+	}
+
+	info := &slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID:            "C1",
+				Created:       slack.JSONTime(1700000000),
+				IsExtShared:   true,
+				NumMembers:    3,
+				PreviousNames: []string{"payments", "payments-old"},
+			},
+			Name:       "payments-oncall",
+			Creator:    "U1",
+			IsArchived: true,
+			Topic:      slack.Topic{Value: "Pager rotation", Creator: "U2", LastSet: slack.JSONTime(1700000100)},
+		},
+	}
+
+	got := toChannelInfo(info, provider.Channel{}, usersMap)
+
+	assert.Equal(t, "#payments-oncall", got.Name)
+	assert.Equal(t, provider.PubChanType, got.Type)
+	assert.Equal(t, "alice", got.Creator)
+	assert.Equal(t, "2023-11-14T22:13:20Z", got.Created)
+	assert.Equal(t, "U2", got.TopicCreator)
+	assert.Equal(t, "2023-11-14T22:15:00Z", got.TopicLastSet)
+	assert.Equal(t, "", got.PurposeLastSet)
+	assert.Equal(t, "payments,payments-old", got.PreviousNames)
+	assert.True(t, got.IsArchived)
+	assert.True(t, got.IsExtShared)
+	assert.Equal(t, 3, got.MemberCount)
+
+	cached := provider.Channel{ID: "C1", Name: "#payments-oncall-cached", MemberCount: 7}
+	info.NumMembers = 0
+	got = toChannelInfo(info, cached, usersMap)
+	assert.Equal(t, "#payments-oncall-cached", got.Name)
+	assert.Equal(t, 7, got.MemberCount)
+}
+
+func TestUnitValidateChannelName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", "project-apollo", false},
+		{"underscores and digits", "team_42", false},
+		{"unicode lowercase", "équipe", false},
+		{"empty", "", true},
+		{"uppercase", "Project", true},
+		{"space", "project apollo", true},
+		{"period", "v1.2", true},
+		{"too long", strings.Repeat("a", 81), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChannelName(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnitFilterChannelsByTypes_Archived(t *testing.T) {
+	active := map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "#general"},
+		"C2": {ID: "C2", Name: "#secret", IsPrivate: true},
+	}
+	archived := map[string]provider.Channel{
+		"C3": {ID: "C3", Name: "#old-project", IsArchived: true},
+		"C4": {ID: "C4", Name: "#old-secret", IsPrivate: true, IsArchived: true},
+	}
+	all := mergeChannels(active, archived)
+	require.Len(t, all, 4)
+
+	ids := func(channels []provider.Channel) []string {
+		var out []string
+		for _, c := range channels {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"C1", "C2"}, ids(filterChannelsByTypes(all, []string{"public_channel", "private_channel"})))
+	assert.ElementsMatch(t, []string{"C3", "C4"}, ids(filterChannelsByTypes(all, []string{provider.ArchivedChanType})))
+	assert.ElementsMatch(t, []string{"C1", "C3", "C4"}, ids(filterChannelsByTypes(all, []string{"public_channel", provider.ArchivedChanType})))
+}
@@ -4,17 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/gocarina/gocsv"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -27,8 +31,37 @@ const (
 	defaultConversationsNumericLimit    = 50
 	defaultConversationsExpressionLimit = "1d"
 	maxFileSizeBytes                    = 5 * 1024 * 1024 // 5MB limit
+	defaultUnreadMessagesLimit          = 20
+	maxUnreadMessagesWithoutReadMark    = 5
+	defaultScheduledMessagesLimit       = 100
+	maxScheduleAhead                    = 120 * 24 * time.Hour // chat.scheduleMessage limit
+	defaultFilesLimit                   = 20
+	maxFilesLimit                       = 100
+	defaultSavedLimit                   = 20
+	maxSavedLimit                       = 100
+	maxSavedMessageLookups              = 20 // single message lookups per saved_list page
+	defaultReactionsLimit               = 20
+	maxReactionsLimit                   = 100
+	maxReactionsListPages               = 10
+	maxOpenConversationUsers            = 8 // conversations.open limit, excluding the authenticated user
+	defaultContextSize                  = 5
+	maxContextSize                      = 50
+	defaultContextRepliesLimit          = 50
+	maxContextRepliesLimit              = 200
+	contextHistoryPageSize              = 200
+	maxContextHistoryPages              = 5
 )
 
+var validFileTypes = map[string]struct{}{
+	"all":      {},
+	"spaces":   {},
+	"snippets": {},
+	"images":   {},
+	"gdocs":    {},
+	"zips":     {},
+	"pdfs":     {},
+}
+
 var validFilterKeys = map[string]struct{}{
 	"is":     {},
 	"in":     {},
@@ -40,21 +73,147 @@ var validFilterKeys = map[string]struct{}{
 	"during": {},
 }
 
+// Message is a row of the message tools. Reactions and AttachmentIDs are the
+// flattened CSV forms of ReactionList and Files, which only JSON carries
 type Message struct {
-	MsgID     string `json:"msgID"`
+	MsgID         string            `json:"msgID"`
+	UserID        string            `json:"userID"`
+	UserName      string            `json:"userName"`
+	RealName      string            `json:"realName"`
+	Channel       string            `json:"channelID"`
+	ThreadTs      string            `json:"threadTs"`
+	Text          string            `json:"text"`
+	Time          string            `json:"time"`
+	Reactions     string            `json:"-"`
+	ReactionList  []MessageReaction `csv:"-" json:"reactions,omitempty"`
+	BotName       string            `json:"botName,omitempty"`
+	FileCount     int               `json:"fileCount,omitempty"`
+	AttachmentIDs string            `json:"-"`
+	Files         []MessageFile     `csv:"-" json:"files,omitempty"`
+	HasMedia      bool              `json:"hasMedia,omitempty"`
+	Permalink     string            `json:"permalink"`
+	Cursor        string            `json:"cursor,omitempty"`
+}
+
+type MessageReaction struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type MessageFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Mimetype string `json:"mimetype"`
+	Size     int    `json:"size"`
+}
+
+type UnreadChannel struct {
+	ChannelID    string `json:"channelID"`
+	ChannelName  string `json:"channelName"`
+	ChannelType  string `json:"channelType"`
+	LastRead     string `json:"lastRead"`
+	Latest       string `json:"latest"`
+	MentionCount int    `json:"mentionCount"`
+}
+
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	PostAt      string `json:"postAt"`
+	DateCreated string `json:"dateCreated"`
+	Text        string `json:"text"`
+	Cursor      string `json:"cursor,omitempty"`
+}
+
+// ContextMessage is a message around a linked one, Position is "before",
+// "after", "parent", "reply" or "message" for the linked message itself
+type ContextMessage struct {
+	Position string `json:"position"`
+	Message
+}
+
+type Bookmark struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channelID"`
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Emoji     string `json:"emoji"`
+	Type      string `json:"type"`
+	Created   string `json:"created"`
+	UpdatedBy string `json:"updatedBy"`
+}
+
+type Reaction struct {
+	Emoji     string `json:"emoji"`
+	Count     int    `json:"count"`
+	UserIDs   string `json:"userIDs"`
+	UserNames string `json:"userNames"`
+}
+
+type ReactedMessage struct {
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	MsgID       string `json:"msgID"`
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	Text        string `json:"text"`
+	Time        string `json:"time"`
+	Reactions   string `json:"reactions"`
+	Permalink   string `json:"permalink"`
+	Cursor      string `json:"cursor,omitempty"`
+}
+
+type SavedItem struct {
+	ItemType    string `json:"itemType"`
+	ChannelID   string `json:"channelID"`
+	ChannelName string `json:"channelName"`
+	MsgID       string `json:"msgID"`
+	FileID      string `json:"fileID"`
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	RealName    string `json:"realName"`
+	Text        string `json:"text"`
+	DateSaved   string `json:"dateSaved"`
+	DateDue     string `json:"dateDue"`
+	State       string `json:"state"`
+	Permalink   string `json:"permalink"`
+	Cursor      string `json:"cursor,omitempty"`
+}
+
+type File struct {
+	FileID    string `json:"fileID"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Mimetype  string `json:"mimetype"`
+	Filetype  string `json:"filetype"`
+	Size      int    `json:"size"`
 	UserID    string `json:"userID"`
-	UserName  string `json:"userUser"`
-	RealName  string `json:"realName"`
-	Channel   string `json:"channelID"`
-	ThreadTs  string `json:"ThreadTs"`
-	Text      string `json:"text"`
-	Time      string `json:"time"`
-	Reactions string `json:"reactions,omitempty"`
-	BotName   string `json:"botName,omitempty"`
-	FileCount int    `json:"fileCount,omitempty"`
-	AttachmentIDs   string `json:"attachmentIDs,omitempty"`
-	HasMedia  bool   `json:"hasMedia,omitempty"`
-	Cursor    string `json:"cursor"`
+	UserName  string `json:"userName"`
+	Channels  string `json:"channels"`
+	Created   string `json:"created"`
+	Permalink string `json:"permalink"`
+	Cursor    string `json:"cursor,omitempty"`
+}
+
+// FileData is the result of attachment_get_data, Content is base64 encoded
+// unless the file is text
+type FileData struct {
+	FileID   string `json:"file_id"`
+	Filename string `json:"filename"`
+	Mimetype string `json:"mimetype"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding" jsonschema:"enum=none,enum=base64"`
+	Content  string `json:"content"`
+}
+
+type UploadedFile struct {
+	FileID    string `json:"file_id"`
+	Filename  string `json:"filename"`
+	Title     string `json:"title"`
+	Size      int    `json:"size"`
+	ChannelID string `json:"channel_id"`
+	ThreadTs  string `json:"thread_ts"`
 }
 
 type User struct {
@@ -70,6 +229,13 @@ type conversationParams struct {
 	latest   string
 	cursor   string
 	activity bool
+	markRead bool
+}
+
+type markParams struct {
+	channel  string
+	threadTs string
+	ts       string
 }
 
 type searchParams struct {
@@ -78,11 +244,35 @@ type searchParams struct {
 	page  int
 }
 
+type unreadsParams struct {
+	channelTypes    map[string]bool
+	mentionsOnly    bool
+	includeMessages bool
+	messagesLimit   int
+}
+
 type addMessageParams struct {
 	channel     string
 	threadTs    string
 	text        string
 	contentType string
+	postAt      time.Time
+}
+
+type scheduledListParams struct {
+	channel string
+	cursor  string
+	limit   int
+}
+
+type scheduledDeleteParams struct {
+	channel            string
+	scheduledMessageID string
+}
+
+type modifyMessageParams struct {
+	channel   string
+	timestamp string
 }
 
 type addReactionParams struct {
@@ -91,13 +281,87 @@ type addReactionParams struct {
 	emoji     string
 }
 
+type pinParams struct {
+	channel   string
+	timestamp string
+}
+
+type reactionsGetParams struct {
+	channel   string
+	timestamp string
+}
+
+type contextParams struct {
+	channel      string
+	timestamp    string
+	threadTs     string
+	size         int
+	repliesLimit int
+	activity     bool
+}
+
+type reactionsListParams struct {
+	user   string
+	after  time.Time
+	before time.Time
+	limit  int
+	page   int
+}
+
+type savedListParams struct {
+	filter string
+	limit  int
+	cursor string
+}
+
+type savedItemParams struct {
+	channel   string
+	timestamp string
+}
+
+type bookmarkAddParams struct {
+	channel string
+	title   string
+	link    string
+	emoji   string
+}
+
+type bookmarkRemoveParams struct {
+	channel    string
+	bookmarkID string
+}
+
+type filesListParams struct {
+	channel string
+	user    string
+	types   string
+	tsFrom  slack.JSONTime
+	tsTo    slack.JSONTime
+	limit   int
+	page    int
+}
+
 type filesGetParams struct {
 	fileID string
 }
 
+type filesUploadParams struct {
+	channel        string
+	threadTs       string
+	filename       string
+	title          string
+	initialComment string
+	content        []byte
+}
+
 type ConversationsHandler struct {
 	apiProvider *provider.ApiProvider
 	logger      *zap.Logger
+
+	// permalinkBases caches per channel the base URL of permalinks fetched
+	// with chat.getPermalink when the workspace URL is not available
+	permalinkMu    sync.Mutex
+	permalinkBases map[string]string
 }
 
 func NewConversationsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *ConversationsHandler {
@@ -151,20 +415,7 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 		})
 	}
 
-	// marshal CSV
-	csvBytes, err := gocsv.MarshalBytes(&usersList)
-	if err != nil {
-		ch.logger.Error("Failed to marshal users to CSV", zap.Error(err))
-		return nil, err
-	}
-
-	return []mcp.ResourceContents{
-		mcp.TextResourceContents{
-			URI:      "slack://" + ws + "/users",
-			MIMEType: "text/csv",
-			Text:     string(csvBytes),
-		},
-	}, nil
+	return marshalResource("slack://"+ws+"/users", usersList)
 }
 
 // ConversationsAddMessageHandler posts a message and returns it as CSV
@@ -177,7 +428,7 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 		return nil, err
 	}
 
-	params, err := ch.parseParamsToolAddMessage(request)
+	params, err := ch.parseParamsToolAddMessage(ctx, request)
 	if err != nil {
 		ch.logger.Error("Failed to parse add-message params", zap.Error(err))
 		return nil, err
@@ -188,29 +439,14 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 		options = append(options, slack.MsgOptionTS(params.threadTs))
 	}
 
-	switch params.contentType {
-	case "text/plain":
-		options = append(options, slack.MsgOptionDisableMarkdown())
-		options = append(options, slack.MsgOptionText(params.text, false))
-	case "text/markdown":
-		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text)
-		if err != nil {
-			ch.logger.Warn("Markdown parsing error", zap.Error(err))
-			options = append(options, slack.MsgOptionDisableMarkdown())
-			options = append(options, slack.MsgOptionText(params.text, false))
-		} else {
-			options = append(options, slack.MsgOptionBlocks(blocks...))
-		}
-	default:
-		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	contentOptions, err := ch.messageContentOptions(params.text, params.contentType)
+	if err != nil {
+		return nil, err
 	}
+	options = append(options, contentOptions...)
 
-	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
-	if text.IsUnfurlingEnabled(params.text, unfurlOpt, ch.logger) {
-		options = append(options, slack.MsgOptionEnableLinkUnfurl())
-	} else {
-		options = append(options, slack.MsgOptionDisableLinkUnfurl())
-		options = append(options, slack.MsgOptionDisableMediaUnfurl())
+	if !params.postAt.IsZero() {
+		return ch.scheduleMessage(ctx, request, params, options)
 	}
 
 	ch.logger.Debug("Posting Slack message",
@@ -248,49 +484,130 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 	}
 	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	messages := ch.convertMessagesFromHistory(history.Messages, historyParams.ChannelID, false)
-	return marshalMessagesToCSV(messages)
+	messages := ch.convertMessagesFromHistory(ctx, history.Messages, historyParams.ChannelID, false)
+	return marshalRows(request, messages)
 }
 
-// ReactionsAddHandler adds an emoji reaction to a message
-func (ch *ConversationsHandler) ReactionsAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ReactionsAddHandler called", zap.Any("params", request.Params))
+// ConversationsOpenHandler opens a DM with one user or a group DM with several
+// users, or returns the existing one
+func (ch *ConversationsHandler) ConversationsOpenHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsOpenHandler called", zap.Any("params", request.Params))
 
-	// provider readiness
 	if ready, err := ch.apiProvider.IsReady(); !ready {
 		ch.logger.Error("API provider not ready", zap.Error(err))
 		return nil, err
 	}
 
-	params, err := ch.parseParamsToolReaction(request)
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Open conversation tool disabled by default")
+		return nil, errors.New("conversations_open is disabled together with conversations_add_message, " +
+			"set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to enable it")
+	}
+
+	users := strings.Split(request.GetString("users", ""), ",")
+	opened, err := ch.openConversation(ctx, users)
 	if err != nil {
-		ch.logger.Error("Failed to parse add-reaction params", zap.Error(err))
 		return nil, err
 	}
 
-	itemRef := slack.ItemRef{
-		Channel:   params.channel,
-		Timestamp: params.timestamp,
+	info := &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{
+		ID:        opened.ID,
+		IsIM:      opened.IsIM,
+		IsMpIM:    opened.IsMpIM,
+		IsPrivate: opened.IsPrivate,
+	}}}
+	return marshalRows(request, []ChannelInfo{toChannelInfo(info, opened, ch.apiProvider.ProvideUsersMap().Users)})
+}
+
+// openConversation opens an IM for one user or an MPIM for several users,
+// which are resolved like in search filters, and adds it to the channels cache.
+// The ID is not known before opening, so an allowlist policy of
+// SLACK_MCP_ADD_MESSAGE_TOOL rejects it up front and a denylist is checked
+// before the conversation is cached
+func (ch *ConversationsHandler) openConversation(ctx context.Context, users []string) (provider.Channel, error) {
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if isAllowlistPolicy(toolConfig) {
+		ch.logger.Warn("Opening conversations not allowed by allowlist policy", zap.String("policy", toolConfig))
+		return provider.Channel{}, fmt.Errorf("conversations can not be opened when SLACK_MCP_ADD_MESSAGE_TOOL is a list of allowed channels, "+
+			"use the ID of an allowed DM instead, applied policy: %s", toolConfig)
 	}
 
-	ch.logger.Debug("Adding reaction to Slack message",
-		zap.String("channel", params.channel),
-		zap.String("timestamp", params.timestamp),
-		zap.String("emoji", params.emoji),
-	)
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, raw := range users {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		formatted, err := ch.paramFormatUser(raw)
+		if err != nil {
+			ch.logger.Error("Failed to lookup user", zap.String("user", raw), zap.Error(err))
+			return provider.Channel{}, err
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(formatted, "<@"), ">")
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return provider.Channel{}, errors.New("users is required")
+	}
+	if len(ids) > maxOpenConversationUsers {
+		return provider.Channel{}, fmt.Errorf("a group DM can include at most %d users", maxOpenConversationUsers)
+	}
 
-	err = ch.apiProvider.Slack().AddReactionContext(ctx, params.emoji, itemRef)
+	channel, _, _, err := ch.apiProvider.Slack().OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users:    ids,
+		ReturnIM: true,
+	})
 	if err != nil {
-		ch.logger.Error("Slack AddReactionContext failed", zap.Error(err))
-		return nil, err
+		ch.logger.Error("Slack OpenConversationContext failed", zap.Strings("users", ids), zap.Error(err))
+		return provider.Channel{}, err
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Successfully added :%s: reaction to message %s in channel %s", params.emoji, params.timestamp, params.channel)), nil
+	selfID := ""
+	if ar, err := ch.apiProvider.Slack().AuthTest(); err == nil {
+		selfID = ar.UserID
+	}
+	completeOpenedConversation(channel, ids, selfID)
+
+	if !isChannelAllowed(channel.ID) {
+		ch.logger.Warn("Open conversation not allowed for channel", zap.String("channel", channel.ID), zap.String("policy", toolConfig))
+		return provider.Channel{}, fmt.Errorf("conversation %q is not allowed by SLACK_MCP_ADD_MESSAGE_TOOL, applied policy: %s", channel.ID, toolConfig)
+	}
+
+	opened := ch.apiProvider.UpsertChannel(*channel)
+	ch.logger.Debug("Opened conversation", zap.String("channel", opened.ID), zap.String("name", opened.Name))
+	return opened, nil
 }
 
-// ReactionsRemoveHandler removes an emoji reaction from a message
-func (ch *ConversationsHandler) ReactionsRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ReactionsRemoveHandler called", zap.Any("params", request.Params))
+// completeOpenedConversation fills in the type and the members which
+// conversations.open may omit, as they are known from the request
+func completeOpenedConversation(channel *slack.Channel, ids []string, selfID string) {
+	if len(ids) == 1 {
+		channel.IsIM = true
+		if channel.User == "" {
+			channel.User = ids[0]
+		}
+		return
+	}
+
+	channel.IsMpIM = true
+	channel.IsPrivate = true
+	if len(channel.Members) == 0 {
+		channel.Members = append([]string{}, ids...)
+		if selfID != "" {
+			channel.Members = append(channel.Members, selfID)
+		}
+	}
+}
+
+// ConversationsUpdateMessageHandler edits a message and returns it as CSV
+func (ch *ConversationsHandler) ConversationsUpdateMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsUpdateMessageHandler called", zap.Any("params", request.Params))
 
 	// provider readiness
 	if ready, err := ch.apiProvider.IsReady(); !ready {
@@ -298,193 +615,1572 @@ func (ch *ConversationsHandler) ReactionsRemoveHandler(ctx context.Context, requ
 		return nil, err
 	}
 
-	params, err := ch.parseParamsToolReaction(request)
+	params, err := ch.parseParamsToolModifyMessage(request, "conversations_update_message")
 	if err != nil {
-		ch.logger.Error("Failed to parse remove-reaction params", zap.Error(err))
+		ch.logger.Error("Failed to parse update-message params", zap.Error(err))
 		return nil, err
 	}
 
-	itemRef := slack.ItemRef{
-		Channel:   params.channel,
-		Timestamp: params.timestamp,
+	msgText := request.GetString("payload", "")
+	if msgText == "" {
+		ch.logger.Error("Message text missing")
+		return nil, errors.New("text must be a string")
+	}
+	contentType := request.GetString("content_type", "text/markdown")
+	if err := validateContentType(contentType); err != nil {
+		ch.logger.Error("Invalid content_type", zap.String("content_type", contentType))
+		return nil, err
 	}
 
-	ch.logger.Debug("Removing reaction from Slack message",
+	if err := ch.checkMessageAuthor(ctx, params.channel, params.timestamp); err != nil {
+		return nil, err
+	}
+
+	options, err := ch.messageContentOptions(msgText, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Updating Slack message",
 		zap.String("channel", params.channel),
 		zap.String("timestamp", params.timestamp),
-		zap.String("emoji", params.emoji),
+		zap.String("content_type", contentType),
 	)
+	respChannel, respTimestamp, _, err := ch.apiProvider.Slack().UpdateMessageContext(ctx, params.channel, params.timestamp, options...)
+	if err != nil {
+		ch.logger.Error("Slack UpdateMessageContext failed", zap.Error(err))
+		return nil, err
+	}
 
-	err = ch.apiProvider.Slack().RemoveReactionContext(ctx, params.emoji, itemRef)
+	msg, err := ch.fetchMessage(ctx, respChannel, respTimestamp, "")
 	if err != nil {
-		ch.logger.Error("Slack RemoveReactionContext failed", zap.Error(err))
+		ch.logger.Error("Failed to fetch updated message", zap.Error(err))
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Successfully removed :%s: reaction from message %s in channel %s", params.emoji, params.timestamp, params.channel)), nil
+	messages := ch.convertMessagesFromHistory(ctx, []slack.Message{*msg}, respChannel, false)
+	return marshalRows(request, messages)
 }
 
-func (ch *ConversationsHandler) FilesGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("FilesGetHandler called", zap.Any("params", request.Params))
+// ConversationsDeleteMessageHandler deletes a message
+func (ch *ConversationsHandler) ConversationsDeleteMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsDeleteMessageHandler called", zap.Any("params", request.Params))
 
+	// provider readiness
 	if ready, err := ch.apiProvider.IsReady(); !ready {
 		ch.logger.Error("API provider not ready", zap.Error(err))
 		return nil, err
 	}
 
-	params, err := ch.parseParamsToolFilesGet(request)
+	params, err := ch.parseParamsToolModifyMessage(request, "conversations_delete_message")
 	if err != nil {
-		ch.logger.Error("Failed to parse attachment_get_data params", zap.Error(err))
+		ch.logger.Error("Failed to parse delete-message params", zap.Error(err))
 		return nil, err
 	}
 
-	fileInfo, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, params.fileID, 0, 0)
+	if err := ch.checkMessageAuthor(ctx, params.channel, params.timestamp); err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Deleting Slack message",
+		zap.String("channel", params.channel),
+		zap.String("timestamp", params.timestamp),
+	)
+	respChannel, respTimestamp, err := ch.apiProvider.Slack().DeleteMessageContext(ctx, params.channel, params.timestamp)
 	if err != nil {
-		ch.logger.Error("Slack GetFileInfoContext failed", zap.Error(err))
+		ch.logger.Error("Slack DeleteMessageContext failed", zap.Error(err))
 		return nil, err
 	}
 
-	if fileInfo.Size > maxFileSizeBytes {
-		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", fileInfo.Size, maxFileSizeBytes)
+	return marshalStatus(request, fmt.Sprintf("Successfully deleted message %s in channel %s", respTimestamp, respChannel))
+}
+
+// messageContentOptions converts the payload to message options according to
+// the content type and applies the unfurling policy
+func (ch *ConversationsHandler) messageContentOptions(msgText, contentType string) ([]slack.MsgOption, error) {
+	var options []slack.MsgOption
+
+	switch contentType {
+	case "text/plain":
+		options = append(options, slack.MsgOptionDisableMarkdown())
+		options = append(options, slack.MsgOptionText(msgText, false))
+	case "text/markdown":
+		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(msgText)
+		if err != nil {
+			ch.logger.Warn("Markdown parsing error", zap.Error(err))
+			options = append(options, slack.MsgOptionDisableMarkdown())
+			options = append(options, slack.MsgOptionText(msgText, false))
+		} else {
+			options = append(options, slack.MsgOptionBlocks(blocks...))
+		}
+	case contentTypeBlocks:
+		blocks, fallback, err := parseBlocksPayload(msgText)
+		if err != nil {
+			ch.logger.Warn("Invalid Block Kit payload", zap.Error(err))
+			return nil, err
+		}
+		options = append(options, slack.MsgOptionBlocks(blocks.BlockSet...))
+		if fallback != "" {
+			options = append(options, slack.MsgOptionText(fallback, false))
+		}
+	case contentTypeAttachments:
+		attachments, msgText, err := parseAttachmentsPayload(msgText)
+		if err != nil {
+			ch.logger.Warn("Invalid attachments payload", zap.Error(err))
+			return nil, err
+		}
+		options = append(options, slack.MsgOptionAttachments(attachments...))
+		if msgText != "" {
+			options = append(options, slack.MsgOptionText(msgText, false))
+		}
+	default:
+		return nil, validateContentType(contentType)
 	}
 
-	var buf bytes.Buffer
-	downloadURL := fileInfo.URLPrivateDownload
-	if downloadURL == "" {
-		downloadURL = fileInfo.URLPrivate
+	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
+	if text.IsUnfurlingEnabled(msgText, unfurlOpt, ch.logger) {
+		options = append(options, slack.MsgOptionEnableLinkUnfurl())
+	} else {
+		options = append(options, slack.MsgOptionDisableLinkUnfurl())
+		options = append(options, slack.MsgOptionDisableMediaUnfurl())
 	}
-	if downloadURL == "" {
-		return nil, errors.New("file has no downloadable URL")
+
+	return options, nil
+}
+
+// fetchMessage returns a single message by its timestamp, it looks up the
+// channel history first and falls back to thread replies. threadTs is the
+// parent of a reply when it is known, e.g. from a permalink, and skips the
+// history lookup
+func (ch *ConversationsHandler) fetchMessage(ctx context.Context, channel, ts, threadTs string) (*slack.Message, error) {
+	if threadTs == "" || threadTs == ts {
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Limit:     1,
+			Oldest:    ts,
+			Latest:    ts,
+			Inclusive: true,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return nil, err
+		}
+		for _, msg := range history.Messages {
+			if msg.Timestamp == ts {
+				return &msg, nil
+			}
+		}
 	}
 
-	err = ch.apiProvider.Slack().GetFileContext(ctx, downloadURL, &buf)
+	parentTs := threadTs
+	if parentTs == "" {
+		parentTs = ts
+	}
+	// conversations.replies returns the thread parent first, the reply
+	// itself is the second message
+	replies, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: parentTs,
+		Limit:     2,
+		Oldest:    ts,
+		Latest:    ts,
+		Inclusive: true,
+	})
 	if err != nil {
-		ch.logger.Error("Slack GetFileContext failed", zap.Error(err))
+		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
 		return nil, err
 	}
+	for _, msg := range replies {
+		if msg.Timestamp == ts {
+			return &msg, nil
+		}
+	}
 
-	content := buf.Bytes()
-	encoding := "none"
-	var contentStr string
+	return nil, fmt.Errorf("message %s not found in channel %s", ts, channel)
+}
 
-	if isTextMimetype(fileInfo.Mimetype) {
-		contentStr = string(content)
-	} else {
-		contentStr = base64.StdEncoding.EncodeToString(content)
-		encoding = "base64"
+// checkMessageAuthor guards against modifying messages which were not posted
+// by the authenticated user unless SLACK_MCP_MODIFY_ANY_MESSAGE is enabled
+func (ch *ConversationsHandler) checkMessageAuthor(ctx context.Context, channel, ts string) error {
+	toolConfig := os.Getenv("SLACK_MCP_MODIFY_ANY_MESSAGE")
+	if toolConfig == "1" || toolConfig == "true" || toolConfig == "yes" {
+		return nil
 	}
 
-	result := fmt.Sprintf(`{"file_id":"%s","filename":"%s","mimetype":"%s","size":%d,"encoding":"%s","content":"%s"}`,
-		fileInfo.ID,
-		escapeJSON(fileInfo.Name),
-		escapeJSON(fileInfo.Mimetype),
-		len(content),
-		encoding,
-		escapeJSON(contentStr))
-
-	return mcp.NewToolResultText(result), nil
-}
+	ar, err := ch.apiProvider.Slack().AuthTest()
+	if err != nil {
+		ch.logger.Error("Slack AuthTest failed", zap.Error(err))
+		return err
+	}
 
-func isTextMimetype(mimetype string) bool {
-	if strings.HasPrefix(mimetype, "text/") {
-		return true
+	msg, err := ch.fetchMessage(ctx, channel, ts, "")
+	if err != nil {
+		return err
 	}
-	textMimetypes := map[string]bool{
-		"application/json":       true,
-		"application/xml":        true,
-		"application/javascript": true,
-		"application/x-yaml":     true,
-		"application/x-sh":       true,
+
+	if (msg.User != "" && msg.User == ar.UserID) || (msg.BotID != "" && msg.BotID == ar.BotID) {
+		return nil
 	}
-	return textMimetypes[mimetype]
-}
 
-func escapeJSON(s string) string {
-	s = strings.ReplaceAll(s, `\`, `\\`)
-	s = strings.ReplaceAll(s, `"`, `\"`)
-	s = strings.ReplaceAll(s, "\n", `\n`)
-	s = strings.ReplaceAll(s, "\r", `\r`)
-	s = strings.ReplaceAll(s, "\t", `\t`)
-	return s
+	ch.logger.Warn("Refusing to modify message of another author",
+		zap.String("channel", channel),
+		zap.String("timestamp", ts),
+		zap.String("author", msg.User),
+	)
+	return fmt.Errorf("message %s in channel %s was not posted by the authenticated user, "+
+		"set SLACK_MCP_MODIFY_ANY_MESSAGE=true to allow modifying messages of other authors", ts, channel)
 }
 
-// ConversationsHistoryHandler streams conversation history as CSV
-func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// ReactionsAddHandler adds an emoji reaction to a message
+func (ch *ConversationsHandler) ReactionsAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsAddHandler called", zap.Any("params", request.Params))
+
+	// provider readiness
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolReaction(ctx, request)
+	if err != nil {
+		ch.logger.Error("Failed to parse add-reaction params", zap.Error(err))
+		return nil, err
+	}
+
+	itemRef := slack.ItemRef{
+		Channel:   params.channel,
+		Timestamp: params.timestamp,
+	}
+
+	ch.logger.Debug("Adding reaction to Slack message",
+		zap.String("channel", params.channel),
+		zap.String("timestamp", params.timestamp),
+		zap.String("emoji", params.emoji),
+	)
+
+	err = ch.apiProvider.Slack().AddReactionContext(ctx, params.emoji, itemRef)
+	if err != nil {
+		ch.logger.Error("Slack AddReactionContext failed", zap.Error(err))
+		return nil, emojiNameError(ctx, ch.apiProvider, params.emoji, err)
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully added :%s: reaction to message %s in channel %s", params.emoji, params.timestamp, params.channel))
+}
+
+// ReactionsRemoveHandler removes an emoji reaction from a message
+func (ch *ConversationsHandler) ReactionsRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsRemoveHandler called", zap.Any("params", request.Params))
+
+	// provider readiness
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolReaction(ctx, request)
+	if err != nil {
+		ch.logger.Error("Failed to parse remove-reaction params", zap.Error(err))
+		return nil, err
+	}
+
+	itemRef := slack.ItemRef{
+		Channel:   params.channel,
+		Timestamp: params.timestamp,
+	}
+
+	ch.logger.Debug("Removing reaction from Slack message",
+		zap.String("channel", params.channel),
+		zap.String("timestamp", params.timestamp),
+		zap.String("emoji", params.emoji),
+	)
+
+	err = ch.apiProvider.Slack().RemoveReactionContext(ctx, params.emoji, itemRef)
+	if err != nil {
+		ch.logger.Error("Slack RemoveReactionContext failed", zap.Error(err))
+		return nil, emojiNameError(ctx, ch.apiProvider, params.emoji, err)
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully removed :%s: reaction from message %s in channel %s", params.emoji, params.timestamp, params.channel))
+}
+
+// ReactionsGetHandler returns every reaction on a message with the users who
+// reacted
+func (ch *ConversationsHandler) ReactionsGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsGetHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolReactionsGet(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse reactions_get params", zap.Error(err))
+		return nil, err
+	}
+
+	reactions, err := ch.apiProvider.Slack().GetReactionsContext(ctx, slack.NewRefToMessage(params.channel, params.timestamp), slack.GetReactionsParameters{Full: true})
+	if err != nil {
+		ch.logger.Error("Slack GetReactionsContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	result := make([]Reaction, 0, len(reactions))
+	for _, r := range reactions {
+		names := make([]string, 0, len(r.Users))
+		for _, id := range r.Users {
+			name, _, ok := getUserInfo(id, usersMap)
+			if !ok {
+				name = id
+			}
+			names = append(names, name)
+		}
+		result = append(result, Reaction{
+			Emoji:     r.Name,
+			Count:     r.Count,
+			UserIDs:   strings.Join(r.Users, ","),
+			UserNames: strings.Join(names, ","),
+		})
+	}
+
+	return marshalRows(request, result)
+}
+
+// ReactionsListHandler returns messages a user reacted to, optionally limited
+// to messages posted in a period
+func (ch *ConversationsHandler) ReactionsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolReactionsList(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse reactions_list params", zap.Error(err))
+		return nil, err
+	}
+
+	// reactions.list has no date filters, so pages are filtered here and
+	// skipped until something matches. When nothing does, a row with only
+	// the cursor lets the caller continue after the skipped pages
+	var (
+		result []ReactedMessage
+		paging *slack.Paging
+		page   = params.page
+	)
+	for i := 0; i < maxReactionsListPages; i++ {
+		var items []slack.ReactedItem
+		items, paging, err = ch.apiProvider.Slack().ListReactionsContext(ctx, slack.ListReactionsParameters{
+			User:  params.user,
+			Count: params.limit,
+			Page:  page,
+			Full:  true,
+		})
+		if err != nil {
+			ch.logger.Error("Slack ListReactionsContext failed", zap.Error(err))
+			return nil, err
+		}
+
+		result = append(result, ch.convertReactedItems(ctx, items, params)...)
+		if len(result) > 0 || paging == nil || paging.Page >= paging.Pages {
+			break
+		}
+		page = paging.Page + 1
+	}
+
+	if paging != nil && paging.Page < paging.Pages {
+		if len(result) == 0 {
+			result = append(result, ReactedMessage{})
+		}
+		result[len(result)-1].Cursor = encodePageCursor(paging.Page + 1)
+	}
+
+	return marshalRows(request, result)
+}
+
+func (ch *ConversationsHandler) convertReactedItems(ctx context.Context, items []slack.ReactedItem, params *reactionsListParams) []ReactedMessage {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	channelsMap := ch.apiProvider.ProvideChannelsMaps().Channels
+	mentions := newMentionResolver(ch.apiProvider)
+
+	var result []ReactedMessage
+	for _, item := range items {
+		if item.Message == nil {
+			ch.logger.Debug("Skipping unsupported reacted item", zap.String("type", item.Type))
+			continue
+		}
+		msg := item.Message
+
+		if !params.after.IsZero() || !params.before.IsZero() {
+			posted, err := text.TimestampToTime(msg.Timestamp)
+			if err != nil {
+				ch.logger.Warn("Failed to parse message timestamp", zap.String("ts", msg.Timestamp), zap.Error(err))
+				continue
+			}
+			if (!params.after.IsZero() && posted.Before(params.after)) || (!params.before.IsZero() && !posted.Before(params.before)) {
+				continue
+			}
+		}
+
+		// Only the reactions of the requested user, the item carries all
+		var emoji []string
+		for _, r := range item.Reactions {
+			for _, u := range r.Users {
+				if params.user == "" || u == params.user {
+					emoji = append(emoji, r.Name)
+					break
+				}
+			}
+		}
+
+		timestamp, err := text.TimestampToIsoRFC3339(msg.Timestamp)
+		if err != nil {
+			ch.logger.Error("Failed to convert timestamp to RFC3339", zap.Error(err))
+			continue
+		}
+
+		userName, _, _ := getUserInfo(msg.User, usersMap)
+		channelName := ""
+		if c, ok := channelsMap[item.Channel]; ok {
+			channelName = c.Name
+		}
+
+		result = append(result, ReactedMessage{
+			ChannelID:   item.Channel,
+			ChannelName: channelName,
+			MsgID:       msg.Timestamp,
+			UserID:      msg.User,
+			UserName:    userName,
+			Text:        text.ProcessText(messageText(msg.Text, msg.Blocks)+text.AttachmentsTo2CSV(msg.Text, msg.Attachments), mentions),
+			Time:        timestamp,
+			Reactions:   strings.Join(emoji, "|"),
+			Permalink:   ch.messagePermalink(ctx, item.Channel, msg.Timestamp, msg.ThreadTimestamp),
+		})
+	}
+	return result
+}
+
+// PinsListHandler returns messages and files pinned to a channel as CSV
+func (ch *ConversationsHandler) PinsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("PinsListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse pins_list params", zap.Error(err))
+		return nil, err
+	}
+
+	items, _, err := ch.apiProvider.Slack().ListPinsContext(ctx, channel)
+	if err != nil {
+		ch.logger.Error("Slack ListPinsContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	var pinned []slack.Message
+	for _, item := range items {
+		switch {
+		case item.Message != nil:
+			pinned = append(pinned, *item.Message)
+		case item.File != nil:
+			// Pinned files have no message of their own, present them as one
+			// so that they can be fetched with attachment_get_data
+			pinned = append(pinned, slack.Message{Msg: slack.Msg{
+				Timestamp: fmt.Sprintf("%d.000000", item.File.Created),
+				User:      item.File.User,
+				Text:      item.File.Title,
+				Files:     []slack.File{*item.File},
+			}})
+		default:
+			ch.logger.Debug("Skipping unsupported pinned item", zap.String("type", item.Type))
+		}
+	}
+
+	ch.logger.Debug("Fetched pinned items", zap.String("channel", channel), zap.Int("count", len(pinned)))
+
+	messages := ch.convertMessagesFromHistory(ctx, pinned, channel, true)
+	return marshalRows(request, messages)
+}
+
+// PinsAddHandler pins a message to a channel
+func (ch *ConversationsHandler) PinsAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("PinsAddHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolPin(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse add-pin params", zap.Error(err))
+		return nil, err
+	}
+
+	err = ch.apiProvider.Slack().AddPinContext(ctx, params.channel, slack.NewRefToMessage(params.channel, params.timestamp))
+	if err != nil {
+		ch.logger.Error("Slack AddPinContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully pinned message %s in channel %s", params.timestamp, params.channel))
+}
+
+// PinsRemoveHandler unpins a message from a channel
+func (ch *ConversationsHandler) PinsRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("PinsRemoveHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolPin(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse remove-pin params", zap.Error(err))
+		return nil, err
+	}
+
+	err = ch.apiProvider.Slack().RemovePinContext(ctx, params.channel, slack.NewRefToMessage(params.channel, params.timestamp))
+	if err != nil {
+		ch.logger.Error("Slack RemovePinContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully unpinned message %s in channel %s", params.timestamp, params.channel))
+}
+
+// BookmarksListHandler returns bookmarks of a channel as CSV
+func (ch *ConversationsHandler) BookmarksListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("BookmarksListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse bookmarks_list params", zap.Error(err))
+		return nil, err
+	}
+
+	bookmarks, err := ch.apiProvider.Slack().ListBookmarksContext(ctx, channel)
+	if err != nil {
+		ch.logger.Error("Slack ListBookmarksContext failed", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+
+	return marshalRows(request, ch.convertBookmarks(bookmarks))
+}
+
+// BookmarksAddHandler adds a link bookmark to a channel and returns it as CSV
+func (ch *ConversationsHandler) BookmarksAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("BookmarksAddHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolBookmarkAdd(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse add-bookmark params", zap.Error(err))
+		return nil, err
+	}
+
+	bookmark, err := ch.apiProvider.Slack().AddBookmarkContext(ctx, params.channel, slack.AddBookmarkParameters{
+		Title: params.title,
+		Type:  "link",
+		Link:  params.link,
+		Emoji: params.emoji,
+	})
+	if err != nil {
+		ch.logger.Error("Slack AddBookmarkContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalRows(request, ch.convertBookmarks([]slack.Bookmark{bookmark}))
+}
+
+// BookmarksRemoveHandler removes a bookmark from a channel
+func (ch *ConversationsHandler) BookmarksRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("BookmarksRemoveHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolBookmarkRemove(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse remove-bookmark params", zap.Error(err))
+		return nil, err
+	}
+
+	err = ch.apiProvider.Slack().RemoveBookmarkContext(ctx, params.channel, params.bookmarkID)
+	if err != nil {
+		ch.logger.Error("Slack RemoveBookmarkContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully removed bookmark %s from channel %s", params.bookmarkID, params.channel))
+}
+
+func (ch *ConversationsHandler) convertBookmarks(bookmarks []slack.Bookmark) []Bookmark {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+
+	result := make([]Bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		created := ""
+		if b.Created != 0 {
+			created = b.Created.Time().UTC().Format(time.RFC3339)
+		}
+		updatedBy := ""
+		if b.LastUpdatedByUserID != "" {
+			updatedBy, _, _ = getUserInfo(b.LastUpdatedByUserID, usersMap)
+		}
+		result = append(result, Bookmark{
+			ID:        b.ID,
+			ChannelID: b.ChannelID,
+			Title:     b.Title,
+			Link:      b.Link,
+			Emoji:     b.Emoji,
+			Type:      b.Type,
+			Created:   created,
+			UpdatedBy: updatedBy,
+		})
+	}
+	return result
+}
+
+// SavedListHandler returns the "Saved for later" items of the authenticated
+// user, starred items are returned for OAuth tokens
+func (ch *ConversationsHandler) SavedListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("SavedListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolSavedList(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse saved_list params", zap.Error(err))
+		return nil, err
+	}
+
+	var items []SavedItem
+	if ch.apiProvider.IsOAuth() {
+		items, err = ch.starredItems(ctx, params)
+	} else {
+		items, err = ch.savedItems(ctx, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Fetched saved items", zap.Int("count", len(items)))
+	return marshalRows(request, items)
+}
+
+func (ch *ConversationsHandler) savedItems(ctx context.Context, params *savedListParams) ([]SavedItem, error) {
+	filter := "saved"
+	if params.filter != "in_progress" {
+		filter = params.filter
+	}
+
+	resp, err := ch.apiProvider.Slack().SavedList(ctx, filter, params.cursor, params.limit)
+	if err != nil {
+		ch.logger.Error("Slack SavedList failed", zap.Error(err))
+		return nil, err
+	}
+
+	// saved.list returns references only, the text has to be fetched
+	messages := ch.fetchSavedMessages(ctx, resp.SavedItems)
+
+	items := make([]SavedItem, 0, len(resp.SavedItems))
+	for _, saved := range resp.SavedItems {
+		var item SavedItem
+		switch saved.ItemType {
+		case "message":
+			msg, ok := messages[saved.ItemID+"/"+saved.TS]
+			if !ok {
+				msg = slack.Message{Msg: slack.Msg{Timestamp: saved.TS}}
+			}
+			item = ch.savedMessage(ctx, saved.ItemID, &msg)
+		case "file":
+			file, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, saved.ItemID, 0, 0)
+			if err != nil {
+				ch.logger.Warn("Failed to fetch saved file", zap.String("file", saved.ItemID), zap.Error(err))
+				file = &slack.File{ID: saved.ItemID}
+			}
+			item = ch.savedFile(file)
+		default:
+			ch.logger.Debug("Skipping unsupported saved item", zap.String("type", saved.ItemType))
+			continue
+		}
+
+		item.DateSaved = unixToRFC3339(saved.DateCreated)
+		item.DateDue = unixToRFC3339(saved.DateDue)
+		item.State = saved.State
+		items = append(items, item)
+	}
+
+	if len(items) > 0 {
+		items[len(items)-1].Cursor = resp.ResponseMetadata.NextCursor
+	}
+	return items, nil
+}
+
+// fetchSavedMessages returns the saved messages keyed by channel/ts. Messages
+// of a channel are fetched with one history call spanning all of them, the
+// remaining ones, e.g. thread replies, one by one up to maxSavedMessageLookups
+func (ch *ConversationsHandler) fetchSavedMessages(ctx context.Context, saved []edge.SavedItem) map[string]slack.Message {
+	var channels []string
+	byChannel := make(map[string][]string)
+	for _, item := range saved {
+		if item.ItemType != "message" {
+			continue
+		}
+		if _, ok := byChannel[item.ItemID]; !ok {
+			channels = append(channels, item.ItemID)
+		}
+		byChannel[item.ItemID] = append(byChannel[item.ItemID], item.TS)
+	}
+
+	messages := make(map[string]slack.Message)
+	for _, channel := range channels {
+		timestamps := byChannel[channel]
+		if len(timestamps) < 2 {
+			continue
+		}
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Oldest:    slices.Min(timestamps),
+			Latest:    slices.Max(timestamps),
+			Inclusive: true,
+			Limit:     maxSavedLimit,
+		})
+		if err != nil {
+			ch.logger.Warn("Failed to fetch saved messages", zap.String("channel", channel), zap.Error(err))
+			continue
+		}
+		for _, msg := range history.Messages {
+			if slices.Contains(timestamps, msg.Timestamp) {
+				messages[channel+"/"+msg.Timestamp] = msg
+			}
+		}
+	}
+
+	lookups := 0
+	for _, item := range saved {
+		key := item.ItemID + "/" + item.TS
+		if _, ok := messages[key]; ok || item.ItemType != "message" {
+			continue
+		}
+		if lookups == maxSavedMessageLookups {
+			ch.logger.Warn("Too many saved messages to fetch, skipping the rest", zap.Int("lookups", lookups))
+			break
+		}
+		lookups++
+		msg, err := ch.fetchMessage(ctx, item.ItemID, item.TS, "")
+		if err != nil {
+			ch.logger.Warn("Failed to fetch saved message",
+				zap.String("channel", item.ItemID),
+				zap.String("ts", item.TS),
+				zap.Error(err),
+			)
+			continue
+		}
+		messages[key] = *msg
+	}
+	return messages
+}
+
+func (ch *ConversationsHandler) starredItems(ctx context.Context, params *savedListParams) ([]SavedItem, error) {
+	if params.filter != "in_progress" {
+		return nil, errors.New("filter is only supported with browser tokens (xoxc/xoxd), starred items have no state")
+	}
+	page, err := decodePageCursor(params.cursor)
+	if err != nil {
+		ch.logger.Error("Invalid cursor", zap.String("cursor", params.cursor), zap.Error(err))
+		return nil, err
+	}
+
+	starred, paging, err := ch.apiProvider.Slack().ListStarsContext(ctx, slack.StarsParameters{
+		Count: params.limit,
+		Page:  page,
+	})
+	if err != nil {
+		ch.logger.Error("Slack ListStarsContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	items := make([]SavedItem, 0, len(starred))
+	for _, star := range starred {
+		switch {
+		case star.Message != nil:
+			items = append(items, ch.savedMessage(ctx, star.Channel, star.Message))
+		case star.File != nil:
+			items = append(items, ch.savedFile(star.File))
+		default:
+			ch.logger.Debug("Skipping unsupported starred item", zap.String("type", star.Type))
+		}
+	}
+
+	if len(items) > 0 && paging != nil && paging.Page < paging.Pages {
+		items[len(items)-1].Cursor = encodePageCursor(paging.Page + 1)
+	}
+	return items, nil
+}
+
+func (ch *ConversationsHandler) savedMessage(ctx context.Context, channel string, msg *slack.Message) SavedItem {
+	userName, realName, _ := getUserInfo(msg.User, ch.apiProvider.ProvideUsersMap().Users)
+	mentions := newMentionResolver(ch.apiProvider)
+
+	channelName := ""
+	if c, ok := ch.apiProvider.ProvideChannelsMaps().Channels[channel]; ok {
+		channelName = c.Name
+	}
+
+	return SavedItem{
+		ItemType:    "message",
+		ChannelID:   channel,
+		ChannelName: channelName,
+		MsgID:       msg.Timestamp,
+		UserID:      msg.User,
+		UserName:    userName,
+		RealName:    realName,
+		Text:        text.ProcessText(messageText(msg.Text, msg.Blocks)+text.AttachmentsTo2CSV(msg.Text, msg.Attachments), mentions),
+		Permalink:   ch.messagePermalink(ctx, channel, msg.Timestamp, msg.ThreadTimestamp),
+	}
+}
+
+func (ch *ConversationsHandler) savedFile(file *slack.File) SavedItem {
+	userName, realName, _ := getUserInfo(file.User, ch.apiProvider.ProvideUsersMap().Users)
+
+	title := file.Title
+	if title == "" {
+		title = file.Name
+	}
+
+	return SavedItem{
+		ItemType:  "file",
+		FileID:    file.ID,
+		UserID:    file.User,
+		UserName:  userName,
+		RealName:  realName,
+		Text:      title,
+		Permalink: file.Permalink,
+	}
+}
+
+// SavedAddHandler saves a message for later
+func (ch *ConversationsHandler) SavedAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("SavedAddHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolSavedItem(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse saved_add params", zap.Error(err))
+		return nil, err
+	}
+
+	if ch.apiProvider.IsOAuth() {
+		err = ch.apiProvider.Slack().AddStarContext(ctx, params.channel, slack.NewRefToMessage(params.channel, params.timestamp))
+	} else {
+		err = ch.apiProvider.Slack().SavedAdd(ctx, params.channel, params.timestamp)
+	}
+	if err != nil {
+		ch.logger.Error("Slack save message failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully saved message %s in channel %s for later", params.timestamp, params.channel))
+}
+
+// SavedRemoveHandler removes a message from the saved items
+func (ch *ConversationsHandler) SavedRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("SavedRemoveHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolSavedItem(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse saved_remove params", zap.Error(err))
+		return nil, err
+	}
+
+	if ch.apiProvider.IsOAuth() {
+		err = ch.apiProvider.Slack().RemoveStarContext(ctx, params.channel, slack.NewRefToMessage(params.channel, params.timestamp))
+	} else {
+		err = ch.apiProvider.Slack().SavedDelete(ctx, params.channel, params.timestamp)
+	}
+	if err != nil {
+		ch.logger.Error("Slack unsave message failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully removed message %s in channel %s from saved items", params.timestamp, params.channel))
+}
+
+func (ch *ConversationsHandler) FilesGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesGetHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolFilesGet(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse attachment_get_data params", zap.Error(err))
+		return nil, err
+	}
+
+	fileInfo, _, _, err := ch.apiProvider.Slack().GetFileInfoContext(ctx, params.fileID, 0, 0)
+	if err != nil {
+		ch.logger.Error("Slack GetFileInfoContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	if fileInfo.Size > maxFileSizeBytes {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", fileInfo.Size, maxFileSizeBytes)
+	}
+
+	var buf bytes.Buffer
+	downloadURL := fileInfo.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = fileInfo.URLPrivate
+	}
+	if downloadURL == "" {
+		return nil, errors.New("file has no downloadable URL")
+	}
+
+	err = ch.apiProvider.Slack().GetFileContext(ctx, downloadURL, &buf)
+	if err != nil {
+		ch.logger.Error("Slack GetFileContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	content := buf.Bytes()
+	encoding := "none"
+	var contentStr string
+
+	if isTextMimetype(fileInfo.Mimetype) {
+		contentStr = string(content)
+	} else {
+		contentStr = base64.StdEncoding.EncodeToString(content)
+		encoding = "base64"
+	}
+
+	data := FileData{
+		FileID:   fileInfo.ID,
+		Filename: fileInfo.Name,
+		Mimetype: fileInfo.Mimetype,
+		Size:     len(content),
+		Encoding: encoding,
+		Content:  contentStr,
+	}
+	result, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultStructured(data, string(result)), nil
+}
+
+// FilesSearchHandler searches files shared in the workspace using the same
+// filters as conversations_search_messages
+func (ch *ConversationsHandler) FilesSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesSearchHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolSearch(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse files_search params", zap.Error(err))
+		return nil, err
+	}
+	if params.query == "" {
+		return nil, errors.New("search_query or at least one filter must be provided")
+	}
+
+	filesRes, err := ch.apiProvider.Slack().SearchFilesContext(ctx, params.query, slack.SearchParameters{
+		Sort:          slack.DEFAULT_SEARCH_SORT,
+		SortDirection: slack.DEFAULT_SEARCH_SORT_DIR,
+		Highlight:     false,
+		Count:         params.limit,
+		Page:          params.page,
+	})
+	if err != nil {
+		ch.logger.Error("Slack SearchFilesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("File search completed", zap.Int("matches", len(filesRes.Matches)))
+
+	files := ch.convertFiles(filesRes.Matches)
+	if len(files) > 0 && filesRes.Pagination.Page < filesRes.Pagination.PageCount {
+		files[len(files)-1].Cursor = encodePageCursor(filesRes.Pagination.Page + 1)
+	}
+	return marshalRows(request, files)
+}
+
+// FilesListHandler lists files of a channel, user, type or date range, newest
+// first
+func (ch *ConversationsHandler) FilesListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolFilesList(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse files_list params", zap.Error(err))
+		return nil, err
+	}
+
+	files, paging, err := ch.apiProvider.Slack().GetFilesContext(ctx, slack.GetFilesParameters{
+		Channel:       params.channel,
+		User:          params.user,
+		Types:         params.types,
+		TimestampFrom: params.tsFrom,
+		TimestampTo:   params.tsTo,
+		Count:         params.limit,
+		Page:          params.page,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetFilesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched files", zap.Int("count", len(files)))
+
+	result := ch.convertFiles(files)
+	if len(result) > 0 && paging != nil && paging.Page < paging.Pages {
+		result[len(result)-1].Cursor = encodePageCursor(paging.Page + 1)
+	}
+	return marshalRows(request, result)
+}
+
+func (ch *ConversationsHandler) convertFiles(files []slack.File) []File {
+	usersMap := ch.apiProvider.ProvideUsersMap().Users
+	channelsMap := ch.apiProvider.ProvideChannelsMaps().Channels
+
+	result := make([]File, 0, len(files))
+	for _, f := range files {
+		userName, _, _ := getUserInfo(f.User, usersMap)
+
+		var channels []string
+		for _, ids := range [][]string{f.Channels, f.Groups, f.IMs} {
+			for _, id := range ids {
+				if c, ok := channelsMap[id]; ok && c.Name != "" {
+					channels = append(channels, c.Name)
+				} else {
+					channels = append(channels, id)
+				}
+			}
+		}
+
+		created := ""
+		if f.Created != 0 {
+			created = f.Created.Time().UTC().Format(time.RFC3339)
+		}
+
+		result = append(result, File{
+			FileID:    f.ID,
+			Name:      f.Name,
+			Title:     f.Title,
+			Mimetype:  f.Mimetype,
+			Filetype:  f.Filetype,
+			Size:      f.Size,
+			UserID:    f.User,
+			UserName:  userName,
+			Channels:  strings.Join(channels, ","),
+			Created:   created,
+			Permalink: f.Permalink,
+		})
+	}
+	return result
+}
+
+// FilesUploadHandler uploads text or base64 encoded content as a file to a channel or thread
+func (ch *ConversationsHandler) FilesUploadHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesUploadHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolFilesUpload(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse attachment_upload params", zap.Error(err))
+		return nil, err
+	}
+
+	ch.logger.Debug("Uploading Slack file",
+		zap.String("channel", params.channel),
+		zap.String("thread_ts", params.threadTs),
+		zap.String("filename", params.filename),
+		zap.Int("size", len(params.content)),
+	)
+	file, err := ch.apiProvider.Slack().UploadFileV2Context(ctx, slack.UploadFileV2Parameters{
+		Reader:          bytes.NewReader(params.content),
+		FileSize:        len(params.content),
+		Filename:        params.filename,
+		Title:           params.title,
+		InitialComment:  params.initialComment,
+		Channel:         params.channel,
+		ThreadTimestamp: params.threadTs,
+	})
+	if err != nil {
+		ch.logger.Error("Slack UploadFileV2Context failed", zap.Error(err))
+		return nil, err
+	}
+
+	data := UploadedFile{
+		FileID:    file.ID,
+		Filename:  params.filename,
+		Title:     file.Title,
+		Size:      len(params.content),
+		ChannelID: params.channel,
+		ThreadTs:  params.threadTs,
+	}
+	result, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultStructured(data, string(result)), nil
+}
+
+func isTextMimetype(mimetype string) bool {
+	if strings.HasPrefix(mimetype, "text/") {
+		return true
+	}
+	textMimetypes := map[string]bool{
+		"application/json":       true,
+		"application/xml":        true,
+		"application/javascript": true,
+		"application/x-yaml":     true,
+		"application/x-sh":       true,
+	}
+	return textMimetypes[mimetype]
+}
+
+// ConversationsHistoryHandler streams conversation history as CSV
+func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ch.logger.Debug("ConversationsHistoryHandler called", zap.Any("params", request.Params))
 
-	params, err := ch.parseParamsToolConversations(request)
-	if err != nil {
-		ch.logger.Error("Failed to parse history params", zap.Error(err))
-		return nil, err
+	params, err := ch.parseParamsToolConversations(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse history params", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("History params parsed",
+		zap.String("channel", params.channel),
+		zap.Int("limit", params.limit),
+		zap.String("oldest", params.oldest),
+		zap.String("latest", params.latest),
+		zap.Bool("include_activity", params.activity),
+	)
+
+	historyParams := slack.GetConversationHistoryParameters{
+		ChannelID: params.channel,
+		Limit:     params.limit,
+		Oldest:    params.oldest,
+		Latest:    params.latest,
+		Cursor:    params.cursor,
+		Inclusive: false,
+	}
+	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+	if err != nil {
+		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
+
+	// Only the first page holds the newest messages, marking older pages
+	// would move the read mark backwards
+	if params.markRead && params.cursor == "" && len(history.Messages) > 0 {
+		if err := ch.markRead(ctx, params.channel, "", history.Messages[0].Timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := ch.convertMessagesFromHistory(ctx, history.Messages, params.channel, params.activity)
+
+	if len(messages) > 0 && history.HasMore {
+		messages[len(messages)-1].Cursor = history.ResponseMetaData.NextCursor
+	}
+	return marshalStructuredRows(request, messages)
+}
+
+// ConversationsRepliesHandler streams thread replies as CSV
+func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
+
+	params, err := ch.parseParamsToolConversations(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse replies params", zap.Error(err))
+		return nil, err
+	}
+	threadTs := request.GetString("thread_ts", "")
+	if threadTs == "" {
+		ch.logger.Error("thread_ts not provided for replies", zap.String("thread_ts", threadTs))
+		return nil, errors.New("thread_ts must be a string")
+	}
+	if params.markRead && ch.apiProvider.IsOAuth() {
+		return nil, errThreadMarkUnsupported
+	}
+
+	repliesParams := slack.GetConversationRepliesParameters{
+		ChannelID: params.channel,
+		Timestamp: threadTs,
+		Limit:     params.limit,
+		Oldest:    params.oldest,
+		Latest:    params.latest,
+		Cursor:    params.cursor,
+		Inclusive: false,
+	}
+	replies, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &repliesParams)
+	if err != nil {
+		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
+
+	if params.markRead && len(replies) > 0 {
+		if err := ch.markRead(ctx, params.channel, threadTs, replies[len(replies)-1].Timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := ch.convertMessagesFromHistory(ctx, replies, params.channel, params.activity)
+	if len(messages) > 0 && hasMore {
+		messages[len(messages)-1].Cursor = nextCursor
+	}
+	return marshalStructuredRows(request, messages)
+}
+
+// ConversationsContextHandler returns a message with its thread and the
+// messages posted around it in the channel as one CSV in reading order
+func (ch *ConversationsHandler) ConversationsContextHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsContextHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolContext(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse context params", zap.Error(err))
+		return nil, err
+	}
+
+	var (
+		target  *slack.Message
+		replies []slack.Message
+	)
+	threadTs := params.threadTs
+	if threadTs == "" {
+		target, err = ch.fetchMessage(ctx, params.channel, params.timestamp, "")
+		if err != nil {
+			return nil, err
+		}
+		threadTs = target.ThreadTimestamp
+	}
+	// Replies are not part of the channel history, their surroundings are
+	// the ones of the thread parent
+	anchorTs := params.timestamp
+	if threadTs != "" {
+		anchorTs = threadTs
+	}
+
+	if threadTs != "" {
+		replies, _, _, err = ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: params.channel,
+			Timestamp: threadTs,
+			Limit:     params.repliesLimit,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+			return nil, err
+		}
+		if i := slices.IndexFunc(replies, func(m slack.Message) bool { return m.Timestamp == params.timestamp }); i >= 0 {
+			target = &replies[i]
+		} else {
+			// The message is past replies_limit, it is still part of the
+			// context
+			if target == nil {
+				target, err = ch.fetchMessage(ctx, params.channel, params.timestamp, threadTs)
+				if err != nil {
+					return nil, err
+				}
+			}
+			replies = append(replies, *target)
+		}
+	}
+
+	anchor := *target
+	if len(replies) > 0 && replies[0].Timestamp == threadTs {
+		anchor = replies[0]
+		replies = replies[1:]
 	}
-	ch.logger.Debug("History params parsed",
+
+	// A limit of 0 is Slack's default page size, the surroundings are left
+	// out instead
+	var before, after []slack.Message
+	if params.size > 0 {
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: params.channel,
+			Latest:    anchorTs,
+			Limit:     params.size,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return nil, err
+		}
+		before = history.Messages
+
+		after, err = ch.historyAfter(ctx, params.channel, anchorTs, params.size)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ch.logger.Debug("Fetched message context",
 		zap.String("channel", params.channel),
-		zap.Int("limit", params.limit),
-		zap.String("oldest", params.oldest),
-		zap.String("latest", params.latest),
-		zap.Bool("include_activity", params.activity),
+		zap.String("anchor", anchorTs),
+		zap.Int("before", len(before)),
+		zap.Int("after", len(after)),
+		zap.Int("replies", len(replies)),
 	)
 
-	historyParams := slack.GetConversationHistoryParameters{
-		ChannelID: params.channel,
-		Limit:     params.limit,
-		Oldest:    params.oldest,
-		Latest:    params.latest,
-		Cursor:    params.cursor,
-		Inclusive: false,
+	anchorPosition := "parent"
+	if anchor.Timestamp == params.timestamp {
+		anchorPosition = "message"
 	}
-	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+
+	var messages []ContextMessage
+	add := func(position string, msgs []slack.Message, activity bool) {
+		for _, m := range ch.convertMessagesFromHistory(ctx, sortMessagesByTs(msgs), params.channel, activity) {
+			p := position
+			if m.MsgID == params.timestamp {
+				p = "message"
+			}
+			messages = append(messages, ContextMessage{Position: p, Message: m})
+		}
+	}
+	add("before", before, params.activity)
+	add(anchorPosition, []slack.Message{anchor}, true)
+	add("reply", replies, params.activity)
+	add("after", after, params.activity)
+
+	return marshalRows(request, messages)
+}
+
+// historyAfter returns the size channel messages following ts. History comes
+// newest first from latest on, so when there are more than size messages
+// after ts a window after it is widened until it holds size messages, a
+// window with more messages is paged to its oldest end
+func (ch *ConversationsHandler) historyAfter(ctx context.Context, channel, ts string, size int) ([]slack.Message, error) {
+	sec, err := strconv.ParseInt(strings.SplitN(ts, ".", 2)[0], 10, 64)
 	if err != nil {
-		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
-		return nil, err
+		return nil, fmt.Errorf("invalid timestamp %q: %w", ts, err)
 	}
 
-	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
+	now := time.Now().Unix()
+	latest := ""
+	for window := int64(time.Hour / time.Second); ; window *= 4 {
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Oldest:    ts,
+			Latest:    latest,
+			Limit:     size,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return nil, err
+		}
+		switch {
+		case history.HasMore && latest == "" && sec+window < now:
+			// Narrow down to the first window, widened below
+		case history.HasMore:
+			return ch.oldestInWindow(ctx, channel, ts, latest, size)
+		case len(history.Messages) >= size || latest == "":
+			return history.Messages, nil
+		}
 
-	messages := ch.convertMessagesFromHistory(history.Messages, params.channel, params.activity)
+		latest = ""
+		if sec+window < now {
+			latest = strconv.FormatInt(sec+window, 10) + ".000000"
+		}
+	}
+}
 
-	if len(messages) > 0 && history.HasMore {
-		messages[len(messages)-1].Cursor = history.ResponseMetaData.NextCursor
+// oldestInWindow pages the history between oldest and latest and returns
+// its size oldest messages, at most maxContextHistoryPages are fetched
+func (ch *ConversationsHandler) oldestInWindow(ctx context.Context, channel, oldest, latest string, size int) ([]slack.Message, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Oldest:    oldest,
+		Latest:    latest,
+		Limit:     contextHistoryPageSize,
+	}
+	var msgs []slack.Message
+	for page := 0; page < maxContextHistoryPages; page++ {
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, params)
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return nil, err
+		}
+		msgs = append(msgs, history.Messages...)
+		if !history.HasMore {
+			break
+		}
+		if page+1 == maxContextHistoryPages {
+			ch.logger.Warn("Too many messages after the anchor, following messages are not adjacent",
+				zap.String("channel", channel),
+				zap.String("oldest", oldest),
+			)
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
 	}
-	return marshalMessagesToCSV(messages)
+
+	msgs = sortMessagesByTs(msgs)
+	return msgs[:min(size, len(msgs))], nil
 }
 
-// ConversationsRepliesHandler streams thread replies as CSV
-func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
+// sortMessagesByTs orders messages oldest first, history pages come newest
+// first. Timestamps have a fixed width, so they compare as strings
+func sortMessagesByTs(msgs []slack.Message) []slack.Message {
+	sorted := slices.Clone(msgs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+	return sorted
+}
 
-	params, err := ch.parseParamsToolConversations(request)
+// ConversationsMarkHandler moves the read mark of a channel, DM or thread
+func (ch *ConversationsHandler) ConversationsMarkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsMarkHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolMark(request)
 	if err != nil {
-		ch.logger.Error("Failed to parse replies params", zap.Error(err))
+		ch.logger.Error("Failed to parse mark params", zap.Error(err))
 		return nil, err
 	}
-	threadTs := request.GetString("thread_ts", "")
+
+	if params.ts == "latest" {
+		params.ts, err = ch.latestMessageTimestamp(ctx, params.channel, params.threadTs)
+		if err != nil {
+			return nil, err
+		}
+		if params.ts == "" {
+			return marshalStatus(request, fmt.Sprintf("No messages to mark as read in channel %s", params.channel))
+		}
+	}
+
+	if err := ch.markRead(ctx, params.channel, params.threadTs, params.ts); err != nil {
+		return nil, err
+	}
+
+	if params.threadTs != "" {
+		return marshalStatus(request, fmt.Sprintf("Successfully marked thread %s in channel %s as read up to %s", params.threadTs, params.channel, params.ts))
+	}
+	return marshalStatus(request, fmt.Sprintf("Successfully marked channel %s as read up to %s", params.channel, params.ts))
+}
+
+var errThreadMarkUnsupported = errors.New("marking threads as read is only supported with browser tokens (xoxc/xoxd)")
+
+// markRead moves the read mark of a channel, or of a thread if threadTs is set,
+// to ts
+func (ch *ConversationsHandler) markRead(ctx context.Context, channel, threadTs, ts string) error {
+	ch.logger.Debug("Marking conversation as read",
+		zap.String("channel", channel),
+		zap.String("thread_ts", threadTs),
+		zap.String("ts", ts),
+	)
+
 	if threadTs == "" {
-		ch.logger.Error("thread_ts not provided for replies", zap.String("thread_ts", threadTs))
-		return nil, errors.New("thread_ts must be a string")
+		if err := ch.apiProvider.Slack().MarkConversationContext(ctx, channel, ts); err != nil {
+			ch.logger.Error("Slack MarkConversationContext failed", zap.Error(err))
+			return err
+		}
+		return nil
 	}
 
-	repliesParams := slack.GetConversationRepliesParameters{
-		ChannelID: params.channel,
-		Timestamp: threadTs,
-		Limit:     params.limit,
-		Oldest:    params.oldest,
-		Latest:    params.latest,
-		Cursor:    params.cursor,
-		Inclusive: false,
+	if ch.apiProvider.IsOAuth() {
+		return errThreadMarkUnsupported
 	}
-	replies, hasMore, nextCursor, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &repliesParams)
-	if err != nil {
-		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
-		return nil, err
+	if err := ch.apiProvider.Slack().SubscriptionsThreadMark(ctx, channel, threadTs, ts); err != nil {
+		ch.logger.Error("Slack SubscriptionsThreadMark failed", zap.Error(err))
+		return err
 	}
-	ch.logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
+	return nil
+}
 
-	messages := ch.convertMessagesFromHistory(replies, params.channel, params.activity)
-	if len(messages) > 0 && hasMore {
-		messages[len(messages)-1].Cursor = nextCursor
+// latestMessageTimestamp returns ts of the newest message in a channel or of
+// the newest reply in a thread, or an empty string if there are no messages
+func (ch *ConversationsHandler) latestMessageTimestamp(ctx context.Context, channel, threadTs string) (string, error) {
+	if threadTs != "" {
+		// The parent message carries ts of the latest reply
+		replies, _, _, err := ch.apiProvider.Slack().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: threadTs,
+			Limit:     1,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+			return "", err
+		}
+		if len(replies) == 0 {
+			return "", nil
+		}
+		if replies[0].LatestReply != "" {
+			return replies[0].LatestReply, nil
+		}
+		return replies[0].Timestamp, nil
+	}
+
+	history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Limit:     1,
+	})
+	if err != nil {
+		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		return "", err
 	}
-	return marshalMessagesToCSV(messages)
+	if len(history.Messages) == 0 {
+		return "", nil
+	}
+	return history.Messages[0].Timestamp, nil
 }
 
 func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -511,16 +2207,273 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 	}
 	ch.logger.Debug("Search completed", zap.Int("matches", len(messagesRes.Matches)))
 
-	messages := ch.convertMessagesFromSearch(messagesRes.Matches)
+	messages := ch.convertMessagesFromSearch(ctx, messagesRes.Matches)
 	if len(messages) > 0 && messagesRes.Pagination.Page < messagesRes.Pagination.PageCount {
-		nextCursor := fmt.Sprintf("page:%d", messagesRes.Pagination.Page+1)
-		messages[len(messages)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
+		messages[len(messages)-1].Cursor = encodePageCursor(messagesRes.Pagination.Page + 1)
+	}
+	return marshalStructuredRows(request, messages)
+}
+
+// ConversationsUnreadsHandler lists conversations with unread messages and
+// optionally fetches the unread messages themselves
+func (ch *ConversationsHandler) ConversationsUnreadsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsUnreadsHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolUnreads(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse unreads params", zap.Error(err))
+		return nil, err
+	}
+
+	counts, err := ch.apiProvider.Slack().ClientCounts(ctx)
+	if err != nil {
+		ch.logger.Error("Slack ClientCounts failed", zap.Error(err))
+		return nil, err
+	}
+
+	unreads, readMarks := collectUnreads(counts, ch.apiProvider.ProvideChannelsMaps().Channels, params)
+
+	ch.logger.Debug("Collected unread conversations", zap.Int("count", len(unreads)))
+
+	if !params.includeMessages {
+		return marshalRows(request, unreads)
+	}
+
+	var messages []Message
+	for _, u := range unreads {
+		historyParams := slack.GetConversationHistoryParameters{
+			ChannelID: u.ChannelID,
+			Limit:     params.messagesLimit,
+			Oldest:    readMarks[u.ChannelID],
+			Inclusive: false,
+		}
+		// Without a read mark the whole history counts as unread, only the
+		// latest messages are fetched
+		if historyParams.Oldest == "" {
+			historyParams.Limit = min(params.messagesLimit, maxUnreadMessagesWithoutReadMark)
+		}
+		history, err := ch.apiProvider.Slack().GetConversationHistoryContext(ctx, &historyParams)
+		if err != nil {
+			ch.logger.Warn("GetConversationHistoryContext failed for unread channel",
+				zap.String("channel", u.ChannelID),
+				zap.Error(err),
+			)
+			continue
+		}
+		messages = append(messages, ch.convertMessagesFromHistory(ctx, history.Messages, u.ChannelID, false)...)
+	}
+
+	ch.logger.Debug("Fetched unread messages", zap.Int("count", len(messages)))
+
+	return marshalRows(request, unreads, relatedRows{name: "messages", rows: messages})
+}
+
+// collectUnreads returns the conversations of counts with unread messages or
+// mentions allowed by params, most mentions first and then the most recent
+// ones, and their read marks. Conversations which were never read have an
+// empty read mark
+func collectUnreads(counts edge.ClientCountsResponse, channels map[string]provider.Channel, params *unreadsParams) ([]UnreadChannel, map[string]string) {
+	var (
+		unreads   []UnreadChannel
+		readMarks = make(map[string]string)
+	)
+	collect := func(items []edge.ChannelSnapshot, fallbackType string) {
+		for _, snap := range items {
+			if !snap.HasUnreads && snap.MentionCount == 0 {
+				continue
+			}
+			if params.mentionsOnly && snap.MentionCount == 0 {
+				continue
+			}
+
+			name := snap.ID
+			chanType := fallbackType
+			if c, ok := channels[snap.ID]; ok {
+				name = c.Name
+				chanType = channelType(c)
+			}
+			if !params.channelTypes[chanType] {
+				continue
+			}
+
+			readMark := ""
+			if time.Time(snap.LastRead).UnixMicro() > 0 {
+				readMark = snap.LastRead.SlackString()
+			}
+			lastRead, _ := text.TimestampToIsoRFC3339(readMark)
+			latest, _ := text.TimestampToIsoRFC3339(snap.Latest.SlackString())
+
+			readMarks[snap.ID] = readMark
+			unreads = append(unreads, UnreadChannel{
+				ChannelID:    snap.ID,
+				ChannelName:  name,
+				ChannelType:  chanType,
+				LastRead:     lastRead,
+				Latest:       latest,
+				MentionCount: snap.MentionCount,
+			})
+		}
+	}
+	collect(counts.Channels, provider.PubChanType)
+	collect(counts.MPIMs, "mpim")
+	collect(counts.IMs, "im")
+
+	sort.SliceStable(unreads, func(i, j int) bool {
+		if unreads[i].MentionCount != unreads[j].MentionCount {
+			return unreads[i].MentionCount > unreads[j].MentionCount
+		}
+		return unreads[i].Latest > unreads[j].Latest
+	})
+	return unreads, readMarks
+}
+
+// scheduleMessage schedules a message for later delivery and returns the
+// pending scheduled message
+func (ch *ConversationsHandler) scheduleMessage(ctx context.Context, request mcp.CallToolRequest, params *addMessageParams, options []slack.MsgOption) (*mcp.CallToolResult, error) {
+	postAt := strconv.FormatInt(params.postAt.Unix(), 10)
+
+	ch.logger.Debug("Scheduling Slack message",
+		zap.String("channel", params.channel),
+		zap.String("thread_ts", params.threadTs),
+		zap.String("content_type", params.contentType),
+		zap.Time("post_at", params.postAt),
+	)
+	respChannel, scheduledMessageID, err := ch.apiProvider.Slack().ScheduleMessageContext(ctx, params.channel, postAt, options...)
+	if err != nil {
+		ch.logger.Error("Slack ScheduleMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	scheduled := ch.convertScheduledMessages([]slack.ScheduledMessage{{
+		ID:          scheduledMessageID,
+		Channel:     respChannel,
+		PostAt:      int(params.postAt.Unix()),
+		DateCreated: int(time.Now().Unix()),
+		Text:        params.text,
+	}})
+	return marshalRows(request, scheduled)
+}
+
+// ConversationsScheduledListHandler streams pending scheduled messages as CSV
+func (ch *ConversationsHandler) ConversationsScheduledListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsScheduledListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolScheduledList(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse scheduled-list params", zap.Error(err))
+		return nil, err
+	}
+
+	messages, nextCursor, err := ch.apiProvider.Slack().GetScheduledMessagesContext(ctx, &slack.GetScheduledMessagesParameters{
+		Channel: params.channel,
+		Cursor:  params.cursor,
+		Limit:   params.limit,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetScheduledMessagesContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.logger.Debug("Fetched scheduled messages", zap.Int("count", len(messages)))
+
+	scheduled := ch.convertScheduledMessages(messages)
+	if len(scheduled) > 0 && nextCursor != "" {
+		scheduled[len(scheduled)-1].Cursor = nextCursor
+	}
+	return marshalRows(request, scheduled)
+}
+
+// ConversationsScheduledCancelHandler cancels a pending scheduled message
+func (ch *ConversationsHandler) ConversationsScheduledCancelHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsScheduledCancelHandler called", zap.Any("params", request.Params))
+
+	if ready, err := ch.apiProvider.IsReady(); !ready {
+		ch.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := ch.parseParamsToolScheduledDelete(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse scheduled-cancel params", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = ch.apiProvider.Slack().DeleteScheduledMessageContext(ctx, &slack.DeleteScheduledMessageParameters{
+		Channel:            params.channel,
+		ScheduledMessageID: params.scheduledMessageID,
+	})
+	if err != nil {
+		ch.logger.Error("Slack DeleteScheduledMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	return marshalStatus(request, fmt.Sprintf("Successfully cancelled scheduled message %s in channel %s", params.scheduledMessageID, params.channel))
+}
+
+func (ch *ConversationsHandler) convertScheduledMessages(slackMessages []slack.ScheduledMessage) []ScheduledMessage {
+	channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+	mentions := newMentionResolver(ch.apiProvider)
+	var messages []ScheduledMessage
+
+	for _, msg := range slackMessages {
+		channelName := msg.Channel
+		if c, ok := channelsMaps.Channels[msg.Channel]; ok {
+			channelName = c.Name
+		}
+
+		messages = append(messages, ScheduledMessage{
+			ID:          msg.ID,
+			ChannelID:   msg.Channel,
+			ChannelName: channelName,
+			PostAt:      time.Unix(int64(msg.PostAt), 0).UTC().Format(time.RFC3339),
+			DateCreated: time.Unix(int64(msg.DateCreated), 0).UTC().Format(time.RFC3339),
+			Text:        text.ProcessText(msg.Text, mentions),
+		})
+	}
+	return messages
+}
+
+// userLocation returns the time zone of the authenticated user, falling back
+// to UTC when it is unknown
+func (ch *ConversationsHandler) userLocation() *time.Location {
+	return authenticatedUserLocation(ch.apiProvider, ch.logger)
+}
+
+// authenticatedUserLocation returns the time zone of the authenticated user,
+// falling back to UTC if it is unknown
+func authenticatedUserLocation(apiProvider *provider.ApiProvider, logger *zap.Logger) *time.Location {
+	ar, err := apiProvider.Slack().AuthTest()
+	if err != nil {
+		return time.UTC
+	}
+	u, ok := apiProvider.ProvideUsersMap().Users[ar.UserID]
+	if !ok || u.TZ == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.TZ)
+	if err != nil {
+		logger.Warn("Failed to load user time zone", zap.String("tz", u.TZ), zap.Error(err))
+		return time.UTC
 	}
-	return marshalMessagesToCSV(messages)
+	return loc
 }
 
 func isChannelAllowed(channel string) bool {
-	config := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	return isChannelAllowedByPolicy(channel, os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL"))
+}
+
+// isChannelAllowedByPolicy checks channel against a policy in the format of
+// SLACK_MCP_ADD_MESSAGE_TOOL: true/1, an allowlist or a !-prefixed denylist
+func isChannelAllowedByPolicy(channel, config string) bool {
 	if config == "" || config == "true" || config == "1" {
 		return true
 	}
@@ -538,23 +2491,87 @@ func isChannelAllowed(channel string) bool {
 			}
 		}
 	}
-	return !isNegated
+	return isNegated
+}
+
+// isAllowlistPolicy reports whether config allows only the listed channels
+func isAllowlistPolicy(config string) bool {
+	if config == "" || config == "true" || config == "1" {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimSpace(config), "!")
 }
 
 func (ch *ConversationsHandler) resolveChannelID(channel string) (string, error) {
 	if !strings.HasPrefix(channel, "#") && !strings.HasPrefix(channel, "@") {
 		return channel, nil
 	}
-	channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-	chn, ok := channelsMaps.ChannelsInv[channel]
+	c, ok := ch.apiProvider.LookupChannel(channel)
+	if !ok {
+		return "", channelNotFoundError(ch.apiProvider, channel)
+	}
+	return c.ID, nil
+}
+
+// channelNotFoundError tells apart names which may belong to archived
+// channels that are still being cached
+func channelNotFoundError(apiProvider *provider.ApiProvider, channel string) error {
+	if strings.HasPrefix(channel, "#") && !apiProvider.IsArchivedChannelsReady() {
+		return fmt.Errorf("channel %q not found, archived channels are still being cached, try again later or use the channel ID", channel)
+	}
+	return fmt.Errorf("channel %q not found", channel)
+}
+
+var slackTimestampRe = regexp.MustCompile(`^\d{10}\.\d{6}$`)
+
+// cacheMentionResolver resolves mentions in message text with the users,
+// channels and user groups cached by the provider
+type cacheMentionResolver struct {
+	users            map[string]slack.User
+	channels         map[string]provider.Channel
+	archivedChannels map[string]provider.Channel
+	usergroups       map[string]slack.UserGroup
+}
+
+func newMentionResolver(apiProvider *provider.ApiProvider) *cacheMentionResolver {
+	return &cacheMentionResolver{
+		users:            apiProvider.ProvideUsersMap().Users,
+		channels:         apiProvider.ProvideChannelsMaps().Channels,
+		archivedChannels: apiProvider.ProvideArchivedChannelsMaps().Channels,
+		usergroups:       apiProvider.ProvideUsergroupsMap().Usergroups,
+	}
+}
+
+func (r *cacheMentionResolver) UserName(id string) (string, bool) {
+	u, ok := r.users[id]
+	if !ok || u.Name == "" {
+		return "", false
+	}
+	return u.Name, true
+}
+
+func (r *cacheMentionResolver) ChannelName(id string) (string, bool) {
+	c, ok := r.channels[id]
 	if !ok {
-		return "", fmt.Errorf("channel %q not found", channel)
+		c, ok = r.archivedChannels[id]
+	}
+	if !ok || c.Name == "" {
+		return "", false
 	}
-	return channelsMaps.Channels[chn].ID, nil
+	return strings.TrimPrefix(c.Name, "#"), true
 }
 
-func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack.Message, channel string, includeActivity bool) []Message {
+func (r *cacheMentionResolver) UsergroupHandle(id string) (string, bool) {
+	g, ok := r.usergroups[id]
+	if !ok || g.Handle == "" {
+		return "", false
+	}
+	return g.Handle, true
+}
+
+func (ch *ConversationsHandler) convertMessagesFromHistory(ctx context.Context, slackMessages []slack.Message, channel string, includeActivity bool) []Message {
 	usersMap := ch.apiProvider.ProvideUsersMap()
+	mentions := newMentionResolver(ch.apiProvider)
 	var messages []Message
 	warn := false
 
@@ -579,11 +2596,15 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			continue
 		}
 
-		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText := messageText(msg.Text, msg.Blocks) + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
 
-		var reactionParts []string
+		var (
+			reactionParts []string
+			reactionList  []MessageReaction
+		)
 		for _, r := range msg.Reactions {
 			reactionParts = append(reactionParts, fmt.Sprintf("%s:%d", r.Name, r.Count))
+			reactionList = append(reactionList, MessageReaction{Name: r.Name, Count: r.Count})
 		}
 		reactionsString := strings.Join(reactionParts, "|")
 
@@ -595,26 +2616,33 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 		fileCount := len(msg.Files)
 		hasMedia := fileCount > 0 || hasImageBlocks(msg.Blocks)
 
-		var attachmentIDs []string
+		var (
+			attachmentIDs []string
+			files         []MessageFile
+		)
 		for _, f := range msg.Files {
 			attachmentIDs = append(attachmentIDs, f.ID)
+			files = append(files, MessageFile{ID: f.ID, Name: f.Name, Mimetype: f.Mimetype, Size: f.Size})
 		}
 		attachmentIDsStr := strings.Join(attachmentIDs, ",")
 
 		messages = append(messages, Message{
-			MsgID:     msg.Timestamp,
-			UserID:    msg.User,
-			UserName:  userName,
-			RealName:  realName,
-			Text:      text.ProcessText(msgText),
-			Channel:   channel,
-			ThreadTs:  msg.ThreadTimestamp,
-			Time:      timestamp,
-			Reactions: reactionsString,
-			BotName:   botName,
-			FileCount: fileCount,
-			AttachmentIDs:   attachmentIDsStr,
-			HasMedia:  hasMedia,
+			MsgID:         msg.Timestamp,
+			UserID:        msg.User,
+			UserName:      userName,
+			RealName:      realName,
+			Text:          text.ProcessText(msgText, mentions),
+			Channel:       channel,
+			ThreadTs:      msg.ThreadTimestamp,
+			Time:          timestamp,
+			Reactions:     reactionsString,
+			ReactionList:  reactionList,
+			BotName:       botName,
+			FileCount:     fileCount,
+			AttachmentIDs: attachmentIDsStr,
+			Files:         files,
+			HasMedia:      hasMedia,
+			Permalink:     ch.messagePermalink(ctx, channel, msg.Timestamp, msg.ThreadTimestamp),
 		})
 	}
 
@@ -629,8 +2657,55 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 	return messages
 }
 
-func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.SearchMessage) []Message {
+// messagePermalink returns the link to a message built from the workspace URL.
+// When it is not available the link is fetched with chat.getPermalink once per
+// channel and the links of further messages are built from its base URL
+func (ch *ConversationsHandler) messagePermalink(ctx context.Context, channel, ts, threadTs string) string {
+	if channel == "" || ts == "" {
+		return ""
+	}
+
+	ar, err := ch.apiProvider.Slack().AuthTest()
+	if err == nil && ar.URL != "" {
+		return buildPermalink(ar.URL, channel, ts, threadTs)
+	}
+	if err != nil {
+		ch.logger.Warn("Auth test failed, fetching permalink", zap.Error(err))
+	}
+
+	ch.permalinkMu.Lock()
+	defer ch.permalinkMu.Unlock()
+	if base, ok := ch.permalinkBases[channel]; ok {
+		return buildPermalink(base, channel, ts, threadTs)
+	}
+	if ch.permalinkBases == nil {
+		ch.permalinkBases = make(map[string]string)
+	}
+
+	permalink, err := ch.apiProvider.Slack().GetPermalinkContext(ctx, &slack.PermalinkParameters{
+		Channel: channel,
+		Ts:      ts,
+	})
+	if err != nil {
+		ch.logger.Warn("Slack GetPermalinkContext failed, permalinks not available for channel",
+			zap.String("channel", channel),
+			zap.String("ts", ts),
+			zap.Error(err),
+		)
+		ch.permalinkBases[channel] = ""
+		return ""
+	}
+	base := ""
+	if u, err := url.Parse(permalink); err == nil && u.Host != "" {
+		base = u.Scheme + "://" + u.Host
+	}
+	ch.permalinkBases[channel] = base
+	return permalink
+}
+
+func (ch *ConversationsHandler) convertMessagesFromSearch(ctx context.Context, slackMessages []slack.SearchMessage) []Message {
 	usersMap := ch.apiProvider.ProvideUsersMap()
+	mentions := newMentionResolver(ch.apiProvider)
 	var messages []Message
 	warn := false
 
@@ -651,21 +2726,27 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 			continue
 		}
 
-		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText := messageText(msg.Text, msg.Blocks) + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
 
 		hasMedia := hasImageBlocks(msg.Blocks)
 
+		permalink := msg.Permalink
+		if permalink == "" {
+			permalink = ch.messagePermalink(ctx, msg.Channel.ID, msg.Timestamp, threadTs)
+		}
+
 		messages = append(messages, Message{
 			MsgID:     msg.Timestamp,
 			UserID:    msg.User,
 			UserName:  userName,
 			RealName:  realName,
-			Text:      text.ProcessText(msgText),
+			Text:      text.ProcessText(msgText, mentions),
 			Channel:   fmt.Sprintf("#%s", msg.Channel.Name),
 			ThreadTs:  threadTs,
 			Time:      timestamp,
 			Reactions: "",
 			HasMedia:  hasMedia,
+			Permalink: permalink,
 		})
 	}
 
@@ -690,6 +2771,7 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 	limit := request.GetString("limit", "")
 	cursor := request.GetString("cursor", "")
 	activity := request.GetBool("include_activity_messages", false)
+	markRead := request.GetBool("mark_read", false)
 
 	var (
 		paramLimit  int
@@ -727,118 +2809,555 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 			}
 			return nil, fmt.Errorf("channel %q not found in empty cache", channel)
 		}
-		channelsMaps := ch.apiProvider.ProvideChannelsMaps()
-		chn, ok := channelsMaps.ChannelsInv[channel]
+		c, ok := ch.apiProvider.LookupChannel(channel)
 		if !ok {
 			ch.logger.Error("Channel not found in synced cache", zap.String("channel", channel))
+			if !ch.apiProvider.IsArchivedChannelsReady() {
+				return nil, channelNotFoundError(ch.apiProvider, channel)
+			}
 			return nil, fmt.Errorf("channel %q not found in synced cache. Try to remove old cache file and restart MCP Server", channel)
 		}
-		channel = channelsMaps.Channels[chn].ID
+		channel = c.ID
+	}
+
+	return &conversationParams{
+		channel:  channel,
+		limit:    paramLimit,
+		oldest:   paramOldest,
+		latest:   paramLatest,
+		cursor:   cursor,
+		activity: activity,
+		markRead: markRead,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolMark(request mcp.CallToolRequest) (*markParams, error) {
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		return nil, err
+	}
+
+	threadTs := strings.TrimSpace(request.GetString("thread_ts", ""))
+	if threadTs != "" && ch.apiProvider.IsOAuth() {
+		return nil, errThreadMarkUnsupported
+	}
+
+	ts := strings.TrimSpace(request.GetString("ts", "latest"))
+	if ts == "" {
+		ts = "latest"
+	}
+	if ts != "latest" && !slackTimestampRe.MatchString(ts) {
+		return nil, fmt.Errorf("ts must be 'latest' or a message timestamp in format 1234567890.123456, got %q", ts)
+	}
+
+	return &markParams{
+		channel:  channel,
+		threadTs: threadTs,
+		ts:       ts,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolUnreads(request mcp.CallToolRequest) (*unreadsParams, error) {
+	channelTypes := make(map[string]bool)
+	for _, t := range strings.Split(request.GetString("channel_types", ""), ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !slices.Contains(provider.AllChanTypes, t) {
+			ch.logger.Error("Invalid channel type", zap.String("type", t))
+			return nil, fmt.Errorf("invalid channel type %q, allowed values: %s", t, strings.Join(provider.AllChanTypes, ", "))
+		}
+		channelTypes[t] = true
+	}
+	if len(channelTypes) == 0 {
+		for _, t := range provider.AllChanTypes {
+			channelTypes[t] = true
+		}
+	}
+
+	messagesLimit := request.GetInt("messages_limit", defaultUnreadMessagesLimit)
+	if messagesLimit < 1 || messagesLimit > 100 {
+		ch.logger.Error("Invalid messages_limit", zap.Int("messages_limit", messagesLimit))
+		return nil, errors.New("messages_limit must be an integer between 1 and 100")
+	}
+
+	return &unreadsParams{
+		channelTypes:    channelTypes,
+		mentionsOnly:    request.GetBool("mentions_only", false),
+		includeMessages: request.GetBool("include_messages", false),
+		messagesLimit:   messagesLimit,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolAddMessage(ctx context.Context, request mcp.CallToolRequest) (*addMessageParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Add-message tool disabled by default")
+		return nil, errors.New(
+			"by default, the conversations_add_message tool is disabled to guard Slack workspaces against accidental spamming." +
+				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels" +
+				"to limit where the MCP can post messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890'" +
+				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs",
+		)
+	}
+
+	rawChannel := request.GetString("channel_id", "")
+	if rawChannel == "" {
+		ch.logger.Error("channel_id missing in add-message params")
+		return nil, errors.New("channel_id must be a string")
+	}
+	channel, err := ch.resolveChannelID(rawChannel)
+	if err != nil && strings.HasPrefix(rawChannel, "@") {
+		// A DM or group DM we have never used, e.g. @alice or @alice,@bob
+		var opened provider.Channel
+		opened, err = ch.openConversation(ctx, strings.Split(rawChannel, ","))
+		channel = opened.ID
+	}
+	if err != nil {
+		ch.logger.Error("Channel not found", zap.String("channel", rawChannel), zap.Error(err))
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		ch.logger.Warn("Add-message tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("conversations_add_message tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+
+	threadTs := request.GetString("thread_ts", "")
+	if threadTs != "" && !strings.Contains(threadTs, ".") {
+		ch.logger.Error("Invalid thread_ts format", zap.String("thread_ts", threadTs))
+		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	msgText := request.GetString("payload", "")
+	if msgText == "" {
+		ch.logger.Error("Message text missing")
+		return nil, errors.New("text must be a string")
+	}
+
+	contentType := request.GetString("content_type", "text/markdown")
+	if err := validateContentType(contentType); err != nil {
+		ch.logger.Error("Invalid content_type", zap.String("content_type", contentType))
+		return nil, err
+	}
+
+	var postAt time.Time
+	if rawPostAt := request.GetString("post_at", ""); rawPostAt != "" {
+		now := time.Now().In(ch.userLocation())
+		postAt, err = parsePostAt(rawPostAt, now)
+		if err != nil {
+			ch.logger.Error("Invalid post_at", zap.String("post_at", rawPostAt), zap.Error(err))
+			return nil, err
+		}
+		if !postAt.After(now) {
+			return nil, fmt.Errorf("post_at %s is in the past", postAt.Format(time.RFC3339))
+		}
+		if postAt.Sub(now) > maxScheduleAhead {
+			return nil, fmt.Errorf("post_at %s is too far in the future, messages can be scheduled up to 120 days ahead", postAt.Format(time.RFC3339))
+		}
+	}
+
+	return &addMessageParams{
+		channel:     channel,
+		threadTs:    threadTs,
+		text:        msgText,
+		contentType: contentType,
+		postAt:      postAt,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolScheduledList(request mcp.CallToolRequest) (*scheduledListParams, error) {
+	channel := request.GetString("channel_id", "")
+	if channel != "" {
+		var err error
+		channel, err = ch.resolveChannelID(channel)
+		if err != nil {
+			ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	limit := request.GetInt("limit", defaultScheduledMessagesLimit)
+	if limit < 1 || limit > 1000 {
+		ch.logger.Error("Invalid limit", zap.Int("limit", limit))
+		return nil, errors.New("limit must be an integer between 1 and 1000")
+	}
+
+	return &scheduledListParams{
+		channel: channel,
+		cursor:  request.GetString("cursor", ""),
+		limit:   limit,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolScheduledDelete(request mcp.CallToolRequest) (*scheduledDeleteParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Scheduled messages tools disabled by default")
+		return nil, errors.New(
+			"by default, the conversations_scheduled_cancel tool is disabled to guard Slack workspaces against accidental changes. " +
+				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels " +
+				"to limit where the MCP can manage messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890' " +
+				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs",
+		)
+	}
+
+	channel := request.GetString("channel_id", "")
+	if channel == "" {
+		ch.logger.Error("channel_id missing in scheduled-cancel params")
+		return nil, errors.New("channel_id must be a string")
+	}
+	channel, err := ch.resolveChannelID(channel)
+	if err != nil {
+		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		ch.logger.Warn("Scheduled-cancel tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("conversations_scheduled_cancel tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+
+	scheduledMessageID := request.GetString("scheduled_message_id", "")
+	if scheduledMessageID == "" {
+		return nil, errors.New("scheduled_message_id is required")
+	}
+
+	return &scheduledDeleteParams{
+		channel:            channel,
+		scheduledMessageID: scheduledMessageID,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolModifyMessage(request mcp.CallToolRequest, tool string) (*modifyMessageParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Modify-message tools disabled by default", zap.String("tool", tool))
+		return nil, fmt.Errorf(
+			"by default, the %s tool is disabled to guard Slack workspaces against accidental changes. "+
+				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels "+
+				"to limit where the MCP can modify messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890' "+
+				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs", tool,
+		)
+	}
+
+	channel := request.GetString("channel_id", "")
+	if channel == "" {
+		ch.logger.Error("channel_id missing in modify-message params")
+		return nil, errors.New("channel_id must be a string")
+	}
+	channel, err := ch.resolveChannelID(channel)
+	if err != nil {
+		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		ch.logger.Warn("Modify-message tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("%s tool is not allowed for channel %q, applied policy: %s", tool, channel, toolConfig)
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" || !strings.Contains(timestamp, ".") {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, errors.New("timestamp must be a valid timestamp in format 1234567890.123456")
+	}
+
+	return &modifyMessageParams{
+		channel:   channel,
+		timestamp: timestamp,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolReaction(ctx context.Context, request mcp.CallToolRequest) (*addReactionParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_REACTION_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Reactions tool disabled by default")
+		return nil, errors.New(
+			"by default, the reactions tools are disabled to guard Slack workspaces against accidental spamming. " +
+				"To enable them, set the SLACK_MCP_REACTION_TOOL environment variable to true, 1, or comma separated list of channels " +
+				"to limit where the MCP can manage reactions, e.g. 'SLACK_MCP_REACTION_TOOL=C1234567890,D0987654321', 'SLACK_MCP_REACTION_TOOL=!C1234567890' " +
+				"to enable all except one or 'SLACK_MCP_REACTION_TOOL=true' for all channels and DMs",
+		)
+	}
+
+	channel := request.GetString("channel_id", "")
+	if channel == "" {
+		return nil, errors.New("channel_id is required")
+	}
+	channel, err := ch.resolveChannelID(channel)
+	if err != nil {
+		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	if !isChannelAllowed(channel) {
+		ch.logger.Warn("Reactions tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("reactions tools are not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" {
+		return nil, errors.New("timestamp is required")
+	}
+
+	emoji, err := normalizeEmojiName(request.GetString("emoji", ""))
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEmoji(ctx, ch.apiProvider, ch.logger, emoji); err != nil {
+		return nil, err
+	}
+
+	return &addReactionParams{
+		channel:   channel,
+		timestamp: timestamp,
+		emoji:     emoji,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolReactionsGet(request mcp.CallToolRequest) (*reactionsGetParams, error) {
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := strings.TrimSpace(request.GetString("timestamp", ""))
+	if !slackTimestampRe.MatchString(timestamp) {
+		return nil, fmt.Errorf("timestamp must be in format 1234567890.123456, got %q", timestamp)
+	}
+
+	return &reactionsGetParams{
+		channel:   channel,
+		timestamp: timestamp,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolReactionsList(request mcp.CallToolRequest) (*reactionsListParams, error) {
+	params := &reactionsListParams{
+		limit: request.GetInt("limit", defaultReactionsLimit),
+	}
+	if params.limit < 1 || params.limit > maxReactionsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxReactionsLimit)
+	}
+
+	// reactions.list defaults to the authenticated user, but the user is
+	// needed to pick their reactions out of the items
+	user := strings.TrimSpace(request.GetString("user", ""))
+	if user == "" {
+		ar, err := ch.apiProvider.Slack().AuthTest()
+		if err != nil {
+			ch.logger.Error("Slack AuthTest failed", zap.Error(err))
+			return nil, err
+		}
+		params.user = ar.UserID
+	} else {
+		u, err := lookupUser(ch.apiProvider, user)
+		if err != nil {
+			ch.logger.Error("Failed to lookup user", zap.String("user", user), zap.Error(err))
+			return nil, err
+		}
+		params.user = u.ID
+	}
+
+	var err error
+	params.after, params.before, err = parseDateRange(request.GetString("filter_date_after", ""), request.GetString("filter_date_before", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := request.GetString("cursor", "")
+	params.page, err = decodePageCursor(cursor)
+	if err != nil {
+		ch.logger.Error("Invalid cursor", zap.String("cursor", cursor), zap.Error(err))
+		return nil, err
 	}
 
-	return &conversationParams{
-		channel:  channel,
-		limit:    paramLimit,
-		oldest:   paramOldest,
-		latest:   paramLatest,
-		cursor:   cursor,
-		activity: activity,
-	}, nil
+	return params, nil
 }
 
-func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRequest) (*addMessageParams, error) {
-	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
-	if toolConfig == "" {
-		ch.logger.Error("Add-message tool disabled by default")
-		return nil, errors.New(
-			"by default, the conversations_add_message tool is disabled to guard Slack workspaces against accidental spamming." +
-				"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels" +
-				"to limit where the MCP can post messages, e.g. 'SLACK_MCP_ADD_MESSAGE_TOOL=C1234567890,D0987654321', 'SLACK_MCP_ADD_MESSAGE_TOOL=!C1234567890'" +
-				"to enable all except one or 'SLACK_MCP_ADD_MESSAGE_TOOL=true' for all channels and DMs",
-		)
+func (ch *ConversationsHandler) parseParamsToolContext(request mcp.CallToolRequest) (*contextParams, error) {
+	params := &contextParams{
+		size:         request.GetInt("context_size", defaultContextSize),
+		repliesLimit: request.GetInt("replies_limit", defaultContextRepliesLimit),
+		activity:     request.GetBool("include_activity_messages", false),
+	}
+	if params.size < 0 || params.size > maxContextSize {
+		return nil, fmt.Errorf("context_size must be between 0 and %d", maxContextSize)
+	}
+	if params.repliesLimit < 1 || params.repliesLimit > maxContextRepliesLimit {
+		return nil, fmt.Errorf("replies_limit must be between 1 and %d", maxContextRepliesLimit)
+	}
+
+	if link := strings.TrimSpace(request.GetString("permalink", "")); link != "" {
+		channel, ts, threadTs, err := parsePermalink(link)
+		if err != nil {
+			return nil, err
+		}
+		params.channel, params.timestamp, params.threadTs = channel, ts, threadTs
+		return params, nil
 	}
 
+	if request.GetString("channel_id", "") == "" {
+		return nil, errors.New("either permalink or channel_id and timestamp must be provided")
+	}
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		return nil, err
+	}
+	params.channel = channel
+
+	params.timestamp = strings.TrimSpace(request.GetString("timestamp", ""))
+	if !slackTimestampRe.MatchString(params.timestamp) {
+		return nil, fmt.Errorf("timestamp must be in format 1234567890.123456, got %q", params.timestamp)
+	}
+	return params, nil
+}
+
+// parseParamsToolChannel parses the channel_id of read-only channel tools
+func (ch *ConversationsHandler) parseParamsToolChannel(request mcp.CallToolRequest) (string, error) {
 	channel := request.GetString("channel_id", "")
 	if channel == "" {
-		ch.logger.Error("channel_id missing in add-message params")
-		return nil, errors.New("channel_id must be a string")
+		return "", errors.New("channel_id is required")
 	}
 	channel, err := ch.resolveChannelID(channel)
 	if err != nil {
 		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
-		return nil, err
+		return "", err
 	}
-	if !isChannelAllowed(channel) {
-		ch.logger.Warn("Add-message tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
-		return nil, fmt.Errorf("conversations_add_message tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	return channel, nil
+}
+
+// parseParamsToolWritableChannel parses the channel_id of a write tool and
+// checks it against the channel policy from envName, the tool is disabled
+// while envName is empty
+func (ch *ConversationsHandler) parseParamsToolWritableChannel(request mcp.CallToolRequest, envName, tools string) (string, error) {
+	toolConfig := os.Getenv(envName)
+	if toolConfig == "" {
+		ch.logger.Error("Tool disabled by default", zap.String("env", envName))
+		return "", fmt.Errorf(
+			"by default, the %s tools are disabled to guard Slack workspaces against accidental changes. "+
+				"To enable them, set the %s environment variable to true, 1, or comma separated list of channels "+
+				"to limit where the MCP can manage %s, e.g. '%s=C1234567890,D0987654321', '%s=!C1234567890' "+
+				"to enable all except one or '%s=true' for all channels and DMs",
+			tools, envName, tools, envName, envName, envName,
+		)
 	}
 
-	threadTs := request.GetString("thread_ts", "")
-	if threadTs != "" && !strings.Contains(threadTs, ".") {
-		ch.logger.Error("Invalid thread_ts format", zap.String("thread_ts", threadTs))
-		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	channel, err := ch.parseParamsToolChannel(request)
+	if err != nil {
+		return "", err
+	}
+	if !isChannelAllowedByPolicy(channel, toolConfig) {
+		ch.logger.Warn("Tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return "", fmt.Errorf("%s tools are not allowed for channel %q, applied policy: %s", tools, channel, toolConfig)
 	}
+	return channel, nil
+}
 
-	msgText := request.GetString("payload", "")
-	if msgText == "" {
-		ch.logger.Error("Message text missing")
-		return nil, errors.New("text must be a string")
+func (ch *ConversationsHandler) parseParamsToolPin(request mcp.CallToolRequest) (*pinParams, error) {
+	channel, err := ch.parseParamsToolWritableChannel(request, "SLACK_MCP_PINS_TOOL", "pins")
+	if err != nil {
+		return nil, err
 	}
 
-	contentType := request.GetString("content_type", "text/markdown")
-	if contentType != "text/plain" && contentType != "text/markdown" {
-		ch.logger.Error("Invalid content_type", zap.String("content_type", contentType))
-		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	timestamp := strings.TrimSpace(request.GetString("timestamp", ""))
+	if !slackTimestampRe.MatchString(timestamp) {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, fmt.Errorf("timestamp must be in format 1234567890.123456, got %q", timestamp)
 	}
 
-	return &addMessageParams{
-		channel:     channel,
-		threadTs:    threadTs,
-		text:        msgText,
-		contentType: contentType,
+	return &pinParams{
+		channel:   channel,
+		timestamp: timestamp,
 	}, nil
 }
 
-func (ch *ConversationsHandler) parseParamsToolReaction(request mcp.CallToolRequest) (*addReactionParams, error) {
-	toolConfig := os.Getenv("SLACK_MCP_REACTION_TOOL")
-	if toolConfig == "" {
-		ch.logger.Error("Reactions tool disabled by default")
-		return nil, errors.New(
-			"by default, the reactions tools are disabled to guard Slack workspaces against accidental spamming. " +
-				"To enable them, set the SLACK_MCP_REACTION_TOOL environment variable to true, 1, or comma separated list of channels " +
-				"to limit where the MCP can manage reactions, e.g. 'SLACK_MCP_REACTION_TOOL=C1234567890,D0987654321', 'SLACK_MCP_REACTION_TOOL=!C1234567890' " +
-				"to enable all except one or 'SLACK_MCP_REACTION_TOOL=true' for all channels and DMs",
-		)
+func (ch *ConversationsHandler) parseParamsToolSavedList(request mcp.CallToolRequest) (*savedListParams, error) {
+	filter := strings.TrimSpace(request.GetString("filter", "in_progress"))
+	switch filter {
+	case "":
+		filter = "in_progress"
+	case "in_progress", "completed", "archived":
+	default:
+		return nil, fmt.Errorf("invalid filter %q, allowed values: in_progress, completed, archived", filter)
 	}
 
-	channel := request.GetString("channel_id", "")
-	if channel == "" {
-		return nil, errors.New("channel_id is required")
+	limit := request.GetInt("limit", defaultSavedLimit)
+	if limit < 1 || limit > maxSavedLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxSavedLimit)
 	}
-	channel, err := ch.resolveChannelID(channel)
+
+	return &savedListParams{
+		filter: filter,
+		limit:  limit,
+		cursor: request.GetString("cursor", ""),
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolSavedItem(request mcp.CallToolRequest) (*savedItemParams, error) {
+	channel, err := ch.parseParamsToolWritableChannel(request, "SLACK_MCP_SAVED_TOOL", "saved items")
 	if err != nil {
-		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
 		return nil, err
 	}
-	if !isChannelAllowed(channel) {
-		ch.logger.Warn("Reactions tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
-		return nil, fmt.Errorf("reactions tools are not allowed for channel %q, applied policy: %s", channel, toolConfig)
-	}
 
-	timestamp := request.GetString("timestamp", "")
+	timestamp := strings.TrimSpace(request.GetString("timestamp", ""))
 	if timestamp == "" {
 		return nil, errors.New("timestamp is required")
 	}
-
-	emoji := strings.Trim(request.GetString("emoji", ""), ":")
-	if emoji == "" {
-		return nil, errors.New("emoji is required")
+	if !slackTimestampRe.MatchString(timestamp) {
+		return nil, fmt.Errorf("timestamp must be in format 1234567890.123456, got %q", timestamp)
 	}
 
-	return &addReactionParams{
+	return &savedItemParams{
 		channel:   channel,
 		timestamp: timestamp,
-		emoji:     emoji,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolBookmarkAdd(request mcp.CallToolRequest) (*bookmarkAddParams, error) {
+	channel, err := ch.parseParamsToolWritableChannel(request, "SLACK_MCP_BOOKMARKS_TOOL", "bookmarks")
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(request.GetString("title", ""))
+	if title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	link := strings.TrimSpace(request.GetString("link", ""))
+	if link == "" {
+		return nil, errors.New("link is required")
+	}
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("link must be an absolute http(s) URL, got %q", link)
+	}
+
+	emoji := request.GetString("emoji", "")
+	if emoji != "" {
+		emoji = ":" + strings.Trim(emoji, ":") + ":"
+	}
+
+	return &bookmarkAddParams{
+		channel: channel,
+		title:   title,
+		link:    link,
+		emoji:   emoji,
+	}, nil
+}
+
+func (ch *ConversationsHandler) parseParamsToolBookmarkRemove(request mcp.CallToolRequest) (*bookmarkRemoveParams, error) {
+	channel, err := ch.parseParamsToolWritableChannel(request, "SLACK_MCP_BOOKMARKS_TOOL", "bookmarks")
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkID := strings.TrimSpace(request.GetString("bookmark_id", ""))
+	if bookmarkID == "" {
+		return nil, errors.New("bookmark_id is required")
+	}
+
+	return &bookmarkRemoveParams{
+		channel:    channel,
+		bookmarkID: bookmarkID,
 	}, nil
 }
 
@@ -866,6 +3385,83 @@ func (ch *ConversationsHandler) parseParamsToolFilesGet(request mcp.CallToolRequ
 	}, nil
 }
 
+func (ch *ConversationsHandler) parseParamsToolFilesUpload(request mcp.CallToolRequest) (*filesUploadParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_UPLOAD_TOOL")
+	if toolConfig == "" {
+		ch.logger.Error("Upload tool disabled by default")
+		return nil, errors.New(
+			"by default, the attachment_upload tool is disabled to guard Slack workspaces against accidental spamming. " +
+				"To enable it, set the SLACK_MCP_UPLOAD_TOOL environment variable to true, 1, or comma separated list of channels " +
+				"to limit where the MCP can upload files, e.g. 'SLACK_MCP_UPLOAD_TOOL=C1234567890,D0987654321', 'SLACK_MCP_UPLOAD_TOOL=!C1234567890' " +
+				"to enable all except one or 'SLACK_MCP_UPLOAD_TOOL=true' for all channels and DMs",
+		)
+	}
+
+	channel := request.GetString("channel_id", "")
+	if channel == "" {
+		ch.logger.Error("channel_id missing in attachment_upload params")
+		return nil, errors.New("channel_id must be a string")
+	}
+	channel, err := ch.resolveChannelID(channel)
+	if err != nil {
+		ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+		return nil, err
+	}
+	if !isChannelAllowedByPolicy(channel, toolConfig) {
+		ch.logger.Warn("Upload tool not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+		return nil, fmt.Errorf("attachment_upload tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+	}
+
+	threadTs := request.GetString("thread_ts", "")
+	if threadTs != "" && !strings.Contains(threadTs, ".") {
+		ch.logger.Error("Invalid thread_ts format", zap.String("thread_ts", threadTs))
+		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
+	}
+
+	filename := strings.TrimSpace(request.GetString("filename", ""))
+	if filename == "" {
+		return nil, errors.New("filename is required")
+	}
+
+	textContent := request.GetString("content", "")
+	base64Content := request.GetString("content_base64", "")
+	var content []byte
+	switch {
+	case textContent != "" && base64Content != "":
+		return nil, errors.New("only one of content or content_base64 can be provided")
+	case textContent != "":
+		content = []byte(textContent)
+	case base64Content != "":
+		content, err = base64.StdEncoding.DecodeString(base64Content)
+		if err != nil {
+			ch.logger.Error("Invalid content_base64", zap.Error(err))
+			return nil, fmt.Errorf("content_base64 is not valid base64: %v", err)
+		}
+	default:
+		return nil, errors.New("either content or content_base64 must be provided")
+	}
+	if len(content) == 0 {
+		return nil, errors.New("file content must not be empty")
+	}
+	if len(content) > maxFileSizeBytes {
+		return nil, fmt.Errorf("file size %d bytes exceeds maximum allowed size of %d bytes", len(content), maxFileSizeBytes)
+	}
+
+	title := request.GetString("title", "")
+	if title == "" {
+		title = filename
+	}
+
+	return &filesUploadParams{
+		channel:        channel,
+		threadTs:       threadTs,
+		filename:       filename,
+		title:          title,
+		initialComment: request.GetString("initial_comment", ""),
+		content:        content,
+	}, nil
+}
+
 func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (*searchParams, error) {
 	rawQuery := strings.TrimSpace(req.GetString("search_query", ""))
 	freeText, filters := splitQuery(rawQuery)
@@ -923,28 +3519,10 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	limit := req.GetInt("limit", 100)
 	cursor := req.GetString("cursor", "")
 
-	var (
-		page          int
-		decodedCursor []byte
-	)
-	if cursor != "" {
-		decodedCursor, err = base64.StdEncoding.DecodeString(cursor)
-		if err != nil {
-			ch.logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
-			return nil, fmt.Errorf("invalid cursor: %v", err)
-		}
-		parts := strings.Split(string(decodedCursor), ":")
-		if len(parts) != 2 {
-			ch.logger.Error("Invalid cursor format", zap.String("cursor", cursor))
-			return nil, fmt.Errorf("invalid cursor: %v", cursor)
-		}
-		page, err = strconv.Atoi(parts[1])
-		if err != nil || page < 1 {
-			ch.logger.Error("Invalid cursor page", zap.String("cursor", cursor), zap.Error(err))
-			return nil, fmt.Errorf("invalid cursor page: %v", err)
-		}
-	} else {
-		page = 1
+	page, err := decodePageCursor(cursor)
+	if err != nil {
+		ch.logger.Error("Invalid cursor", zap.String("cursor", cursor), zap.Error(err))
+		return nil, err
 	}
 
 	ch.logger.Debug("Search parameters built",
@@ -959,6 +3537,69 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	}, nil
 }
 
+func (ch *ConversationsHandler) parseParamsToolFilesList(request mcp.CallToolRequest) (*filesListParams, error) {
+	params := &filesListParams{
+		limit: request.GetInt("limit", defaultFilesLimit),
+	}
+	if params.limit < 1 || params.limit > maxFilesLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxFilesLimit)
+	}
+
+	if channel := strings.TrimSpace(request.GetString("channel_id", "")); channel != "" {
+		id, err := ch.resolveChannelID(channel)
+		if err != nil {
+			ch.logger.Error("Channel not found", zap.String("channel", channel), zap.Error(err))
+			return nil, err
+		}
+		params.channel = id
+	}
+
+	if user := strings.TrimSpace(request.GetString("user", "")); user != "" {
+		u, err := lookupUser(ch.apiProvider, user)
+		if err != nil {
+			ch.logger.Error("Failed to lookup user", zap.String("user", user), zap.Error(err))
+			return nil, err
+		}
+		params.user = u.ID
+	}
+
+	if types := strings.TrimSpace(request.GetString("types", "")); types != "" {
+		var normalized []string
+		for _, t := range strings.Split(types, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t == "" {
+				continue
+			}
+			if _, ok := validFileTypes[t]; !ok {
+				return nil, fmt.Errorf("invalid file type %q, allowed values: all, spaces, snippets, images, gdocs, zips, pdfs", t)
+			}
+			normalized = append(normalized, t)
+		}
+		params.types = strings.Join(normalized, ",")
+	}
+
+	after, before, err := parseDateRange(request.GetString("filter_date_after", ""), request.GetString("filter_date_before", ""))
+	if err != nil {
+		return nil, err
+	}
+	if !after.IsZero() {
+		params.tsFrom = slack.JSONTime(after.Unix())
+	}
+	if !before.IsZero() {
+		params.tsTo = slack.JSONTime(before.Unix())
+	}
+
+	cursor := request.GetString("cursor", "")
+	page, err := decodePageCursor(cursor)
+	if err != nil {
+		ch.logger.Error("Invalid cursor", zap.String("cursor", cursor), zap.Error(err))
+		return nil, err
+	}
+	params.page = page
+
+	return params, nil
+}
+
 func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
 	users := ch.apiProvider.ProvideUsersMap()
 	raw = strings.TrimSpace(raw)
@@ -986,10 +3627,10 @@ func (ch *ConversationsHandler) paramFormatChannel(raw string) (string, error) {
 	raw = strings.TrimSpace(raw)
 	cms := ch.apiProvider.ProvideChannelsMaps()
 	if strings.HasPrefix(raw, "#") {
-		if id, ok := cms.ChannelsInv[raw]; ok {
-			return cms.Channels[id].Name, nil
+		if c, ok := ch.apiProvider.LookupChannel(raw); ok {
+			return c.Name, nil
 		}
-		return "", fmt.Errorf("channel %q not found", raw)
+		return "", channelNotFoundError(ch.apiProvider, raw)
 	}
 	// Handle both C (standard channels) and G (private groups/channels) prefixes
 	if strings.HasPrefix(raw, "C") || strings.HasPrefix(raw, "G") {
@@ -1001,12 +3642,50 @@ func (ch *ConversationsHandler) paramFormatChannel(raw string) (string, error) {
 	return "", fmt.Errorf("invalid channel format: %q", raw)
 }
 
-func marshalMessagesToCSV(messages []Message) (*mcp.CallToolResult, error) {
-	csvBytes, err := gocsv.MarshalBytes(&messages)
+func channelType(c provider.Channel) string {
+	switch {
+	case c.IsIM:
+		return "im"
+	case c.IsMpIM:
+		return "mpim"
+	case c.IsPrivate:
+		return provider.PrivateChanType
+	default:
+		return provider.PubChanType
+	}
+}
+
+// decodePageCursor decodes the base64 "page:N" cursor of page based tools, an
+// empty cursor is the first page
+func decodePageCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 1, nil
+	}
+	decodedCursor, err := base64.StdEncoding.DecodeString(cursor)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("invalid cursor: %v", err)
+	}
+	parts := strings.Split(string(decodedCursor), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid cursor: %v", cursor)
 	}
-	return mcp.NewToolResultText(string(csvBytes)), nil
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 1 {
+		return 0, fmt.Errorf("invalid cursor page: %v", err)
+	}
+	return page, nil
+}
+
+func encodePageCursor(page int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("page:%d", page)))
+}
+
+// unixToRFC3339 formats unix seconds, zero means not set
+func unixToRFC3339(sec int64) string {
+	if sec == 0 {
+		return ""
+	}
+	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
 }
 
 func getUserInfo(userID string, usersMap map[string]slack.User) (userName, realName string, ok bool) {
@@ -1064,6 +3743,49 @@ func limitByExpression(limit, defaultLimit string) (slackLimit int, oldest, late
 	return 100, oldest, latest, nil
 }
 
+// buildPermalink builds the link to a message in the format Slack uses for
+// chat.getPermalink, replies link to the message within their thread
+func buildPermalink(workspaceURL, channel, ts, threadTs string) string {
+	if workspaceURL == "" {
+		return ""
+	}
+	permalink := strings.TrimSuffix(workspaceURL, "/") + "/archives/" + channel + "/p" + strings.ReplaceAll(ts, ".", "")
+	if threadTs != "" && threadTs != ts {
+		permalink += "?thread_ts=" + threadTs + "&cid=" + channel
+	}
+	return permalink
+}
+
+// parsePermalink extracts the channel and message timestamp from a link in
+// the format https://<workspace>.slack.com/archives/<channel>/p<ts>, links to
+// replies carry the thread timestamp in the thread_ts query parameter
+func parsePermalink(link string) (channel, ts, threadTs string, err error) {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", "", fmt.Errorf("permalink must be a Slack message URL, got %q", link)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "archives" || !strings.HasPrefix(parts[2], "p") {
+		return "", "", "", fmt.Errorf("permalink must be in format https://<workspace>.slack.com/archives/<channel>/p<timestamp>, got %q", link)
+	}
+	digits := strings.TrimPrefix(parts[2], "p")
+	if len(digits) <= 6 {
+		return "", "", "", fmt.Errorf("permalink has an invalid message timestamp %q", parts[2])
+	}
+	channel = parts[1]
+	ts = digits[:len(digits)-6] + "." + digits[len(digits)-6:]
+	if !slackTimestampRe.MatchString(ts) {
+		return "", "", "", fmt.Errorf("permalink has an invalid message timestamp %q", parts[2])
+	}
+
+	threadTs = u.Query().Get("thread_ts")
+	if threadTs != "" && !slackTimestampRe.MatchString(threadTs) {
+		return "", "", "", fmt.Errorf("permalink has an invalid thread_ts %q", threadTs)
+	}
+	return channel, ts, threadTs, nil
+}
+
 func extractThreadTS(rawurl string) (string, error) {
 	u, err := url.Parse(rawurl)
 	if err != nil {
@@ -1190,6 +3912,119 @@ func parseFlexibleDate(dateStr string) (time.Time, string, error) {
 	return time.Time{}, "", fmt.Errorf("unable to parse date: %s", dateStr)
 }
 
+var (
+	timeOfDayRe = regexp.MustCompile(`(?i)^(.*?)(?:\s*\bat)?\s*\b(\d{1,2})(?::(\d{2}))?\s*([ap]\.?m\.?)?$`)
+	weekdayRe   = regexp.MustCompile(`^(next\s+)?(monday|mon|tuesday|tue|wednesday|wed|thursday|thu|friday|fri|saturday|sat|sunday|sun)$`)
+)
+
+// parsePostAt parses the time a message should be posted at. It accepts unix
+// timestamps, RFC3339, every date format supported by parseFlexibleDate and
+// weekday names, optionally followed by a time of day (e.g. '15:30', '9am',
+// 'Monday 9:00', 'tomorrow at 10am'). Dates and times without an explicit
+// offset are interpreted in the location of now.
+func parsePostAt(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("post_at must not be empty")
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && n > 0 {
+		return time.Unix(n, 0), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	loc := now.Location()
+	datePart := value
+	hour, minute := 0, 0
+	hasTime := false
+	if m := timeOfDayRe.FindStringSubmatch(value); m != nil && (m[3] != "" || m[4] != "") {
+		hour, _ = strconv.Atoi(m[2])
+		if m[3] != "" {
+			minute, _ = strconv.Atoi(m[3])
+		}
+		if m[4] != "" {
+			if hour < 1 || hour > 12 {
+				return time.Time{}, fmt.Errorf("invalid time of day in post_at: %s", value)
+			}
+			pm := strings.HasPrefix(strings.ToLower(m[4]), "p")
+			if pm && hour != 12 {
+				hour += 12
+			} else if !pm && hour == 12 {
+				hour = 0
+			}
+		}
+		if hour > 23 || minute > 59 {
+			return time.Time{}, fmt.Errorf("invalid time of day in post_at: %s", value)
+		}
+		datePart = strings.TrimSpace(m[1])
+		hasTime = true
+	}
+
+	weekdays := map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+
+	lower := strings.ToLower(datePart)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	var day time.Time
+	switch {
+	case lower == "" || lower == "today":
+		day = today
+	case lower == "tomorrow":
+		day = today.AddDate(0, 0, 1)
+	case weekdayRe.MatchString(lower):
+		m := weekdayRe.FindStringSubmatch(lower)
+		days := (int(weekdays[m[2]]) - int(now.Weekday()) + 7) % 7
+		if days == 0 && (m[1] != "" || !hasTime || !today.Add(time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute).After(now)) {
+			days = 7
+		}
+		day = today.AddDate(0, 0, days)
+	default:
+		t, _, err := parseFlexibleDate(datePart)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to parse post_at: %s", value)
+		}
+		day = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+
+	postAt := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+	if lower == "" && !postAt.After(now) {
+		postAt = postAt.AddDate(0, 0, 1)
+	}
+	return postAt, nil
+}
+
+// parseDateRange converts the after and before date filters to a time range
+// with the same semantics as search: both exclude the given day, zero times
+// mean no bound
+func parseDateRange(rawAfter, rawBefore string) (after, before time.Time, err error) {
+	if rawAfter != "" {
+		t, _, err := parseFlexibleDate(rawAfter)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'after' date: %v", err)
+		}
+		after = t.AddDate(0, 0, 1)
+	}
+	if rawBefore != "" {
+		t, _, err := parseFlexibleDate(rawBefore)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'before' date: %v", err)
+		}
+		before = t
+	}
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return time.Time{}, time.Time{}, errors.New("no days left between 'after' and 'before' dates")
+	}
+	return after, before, nil
+}
+
 func buildDateFilters(before, after, on, during string) (map[string]string, error) {
 	out := make(map[string]string)
 	if on != "" {
@@ -1277,6 +4112,15 @@ func buildQuery(freeText []string, filters map[string][]string) string {
 	return strings.Join(out, " ")
 }
 
+// messageText returns the mrkdwn of a message. Apps and the Slack composer
+// put the content in blocks, the text is only a fallback then
+func messageText(msgText string, blocks slack.Blocks) string {
+	if blocksText := text.BlocksToText(blocks); blocksText != "" {
+		return blocksText
+	}
+	return msgText
+}
+
 func hasImageBlocks(blocks slack.Blocks) bool {
 	for _, block := range blocks.BlockSet {
 		if block.BlockType() == slack.MBTImage {
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
@@ -12,13 +13,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/openai/openai-go/responses"
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestIntegrationConversations(t *testing.T) {
@@ -590,3 +597,807 @@ func TestUnitLimitByExpression_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitParsePostAt(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	// Wednesday
+	now := time.Date(2025, 7, 16, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"1752760800", time.Unix(1752760800, 0)},
+		{"2025-07-20T10:00:00Z", time.Date(2025, 7, 20, 10, 0, 0, 0, time.UTC)},
+		{"15:30", time.Date(2025, 7, 16, 15, 30, 0, 0, loc)},
+		{"9am", time.Date(2025, 7, 17, 9, 0, 0, 0, loc)},
+		{"12pm", time.Date(2025, 7, 17, 12, 0, 0, 0, loc)},
+		{"tomorrow", time.Date(2025, 7, 17, 0, 0, 0, 0, loc)},
+		{"tomorrow at 10am", time.Date(2025, 7, 17, 10, 0, 0, 0, loc)},
+		{"2025-07-20 9:15", time.Date(2025, 7, 20, 9, 15, 0, 0, loc)},
+		{"Friday 14:30", time.Date(2025, 7, 18, 14, 30, 0, 0, loc)},
+		{"wednesday 9am", time.Date(2025, 7, 23, 9, 0, 0, 0, loc)},
+		{"wednesday 3pm", time.Date(2025, 7, 16, 15, 0, 0, 0, loc)},
+		{"next wednesday 3pm", time.Date(2025, 7, 23, 15, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parsePostAt(tt.input, now)
+			if err != nil {
+				t.Fatalf("unexpected error for %q: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("for %q expected %s, got %s", tt.input, tt.want, got)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "13pm", "25:00", "someday 9am", "not a date"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if _, err := parsePostAt(input, now); err == nil {
+				t.Errorf("expected error for %q, got nil", input)
+			}
+		})
+	}
+}
+
+func TestUnitIsChannelAllowedByPolicyUnlisted(t *testing.T) {
+	// channels missing from an allowlist are blocked, from a denylist allowed
+	assert.False(t, isChannelAllowedByPolicy("C3", "C1,D2"))
+	assert.False(t, isChannelAllowedByPolicy("D3", "C1, D2"))
+	assert.True(t, isChannelAllowedByPolicy("C3", "!C1"))
+	assert.True(t, isChannelAllowedByPolicy("D3", "!C1, !D2"))
+}
+
+func TestUnitIsChannelAllowedByPolicy(t *testing.T) {
+	tests := []struct {
+		config  string
+		channel string
+		want    bool
+	}{
+		{"true", "C1", true},
+		{"1", "C1", true},
+		{"C1,D2", "C1", true},
+		{"!C1", "C1", false},
+		{"!C1, !D2", "D2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config+"/"+tt.channel, func(t *testing.T) {
+			if got := isChannelAllowedByPolicy(tt.channel, tt.config); got != tt.want {
+				t.Errorf("isChannelAllowedByPolicy(%q, %q) = %v, want %v", tt.channel, tt.config, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnitMentionResolver(t *testing.T) {
+	t.Setenv("SLACK_MCP_COMPACT_TEXT", "")
+	mentions := &cacheMentionResolver{
+		users: map[string]slack.User{
+			"U123": {ID: "U123", Name: "alice"},
+		},
+		channels: map[string]provider.Channel{
+			"C123": {ID: "C123", Name: "#payments"},
+		},
+		archivedChannels: map[string]provider.Channel{
+			"C456": {ID: "C456", Name: "#old-payments"},
+		},
+		usergroups: map[string]slack.UserGroup{
+			"S123": {ID: "S123", Handle: "oncall-backend"},
+		},
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"ping <!subteam^S123>", "ping @oncall-backend"},
+		{"ping <!subteam^S123|@old-handle> now", "ping @oncall-backend now"},
+		{"<!subteam^S999|@frontend> please", "@frontend please"},
+		{"<!subteam^S999>", "@S999"},
+		{"<@U123> and <@U999|bob> and <@U998>", "@alice and @bob and @U998"},
+		{"see <#C123|> and <#C456> and <#C999|general>", "see #payments and #old-payments and #general"},
+		{"<!here> <!channel|channel>", "@here @channel"},
+		{"no mentions", "no mentions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, text.ProcessText(tt.input, mentions))
+		})
+	}
+}
+
+func TestUnitMessageText(t *testing.T) {
+	blocks := slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "*Deploy* failed", false, false), nil, nil),
+	}}
+
+	assert.Equal(t, "*Deploy* failed", messageText("Deploy failed", blocks))
+	assert.Equal(t, "fallback", messageText("fallback", slack.Blocks{}))
+	assert.Equal(t, "fallback", messageText("fallback", slack.Blocks{BlockSet: []slack.Block{slack.NewDividerBlock()}}))
+}
+
+func TestUnitParseParamsToolBookmarkAdd(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+	args := map[string]any{
+		"channel_id": "C123",
+		"title":      "Runbook",
+		"link":       "https://wiki.example.com/runbook",
+		"emoji":      "book",
+	}
+
+	t.Setenv("SLACK_MCP_BOOKMARKS_TOOL", "")
+	_, err := ch.parseParamsToolBookmarkAdd(newRequest(args))
+	require.ErrorContains(t, err, "SLACK_MCP_BOOKMARKS_TOOL")
+
+	t.Setenv("SLACK_MCP_BOOKMARKS_TOOL", "!C123")
+	_, err = ch.parseParamsToolBookmarkAdd(newRequest(args))
+	require.ErrorContains(t, err, "not allowed")
+
+	t.Setenv("SLACK_MCP_BOOKMARKS_TOOL", "C123")
+	params, err := ch.parseParamsToolBookmarkAdd(newRequest(args))
+	require.NoError(t, err)
+	assert.Equal(t, "C123", params.channel)
+	assert.Equal(t, ":book:", params.emoji)
+
+	args["link"] = "javascript:alert(1)"
+	_, err = ch.parseParamsToolBookmarkAdd(newRequest(args))
+	require.ErrorContains(t, err, "http(s) URL")
+}
+
+func TestUnitParseParamsToolMark(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	params, err := ch.parseParamsToolMark(newRequest(map[string]any{"channel_id": "C123"}))
+	require.NoError(t, err)
+	assert.Equal(t, "latest", params.ts)
+
+	params, err = ch.parseParamsToolMark(newRequest(map[string]any{"channel_id": "C123", "ts": "1752760800.123456"}))
+	require.NoError(t, err)
+	assert.Equal(t, "1752760800.123456", params.ts)
+
+	_, err = ch.parseParamsToolMark(newRequest(map[string]any{"channel_id": "C123", "ts": "yesterday"}))
+	require.Error(t, err)
+
+	_, err = ch.parseParamsToolMark(newRequest(map[string]any{}))
+	require.Error(t, err)
+}
+
+func TestUnitBuildPermalink(t *testing.T) {
+	assert.Equal(t,
+		"https://acme.slack.com/archives/C123/p1752760800123456",
+		buildPermalink("https://acme.slack.com/", "C123", "1752760800.123456", ""),
+	)
+	assert.Equal(t,
+		"https://acme.slack.com/archives/C123/p1752760800123456",
+		buildPermalink("https://acme.slack.com/", "C123", "1752760800.123456", "1752760800.123456"),
+	)
+	assert.Equal(t,
+		"https://acme.slack.com/archives/C123/p1752760900000100?thread_ts=1752760800.123456&cid=C123",
+		buildPermalink("https://acme.slack.com", "C123", "1752760900.000100", "1752760800.123456"),
+	)
+	assert.Equal(t, "", buildPermalink("", "C123", "1752760800.123456", ""))
+}
+
+func TestUnitParsePermalink(t *testing.T) {
+	channel, ts, threadTs, err := parsePermalink("https://acme.slack.com/archives/C123/p1752760800123456")
+	require.NoError(t, err)
+	assert.Equal(t, "C123", channel)
+	assert.Equal(t, "1752760800.123456", ts)
+	assert.Equal(t, "", threadTs)
+
+	channel, ts, threadTs, err = parsePermalink(buildPermalink("https://acme.slack.com", "C123", "1752760900.000100", "1752760800.123456"))
+	require.NoError(t, err)
+	assert.Equal(t, "C123", channel)
+	assert.Equal(t, "1752760900.000100", ts)
+	assert.Equal(t, "1752760800.123456", threadTs)
+
+	for _, link := range []string{
+		"C123/p1752760800123456",
+		"https://acme.slack.com/archives/C123",
+		"https://acme.slack.com/files/U123/F123/report.pdf",
+		"https://acme.slack.com/archives/C123/p123",
+		"https://acme.slack.com/archives/C123/p1752760800123456?thread_ts=abc",
+	} {
+		_, _, _, err = parsePermalink(link)
+		assert.Error(t, err, link)
+	}
+}
+
+func TestUnitPageCursor(t *testing.T) {
+	page, err := decodePageCursor("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, page)
+
+	page, err = decodePageCursor(encodePageCursor(3))
+	require.NoError(t, err)
+	assert.Equal(t, 3, page)
+
+	for _, cursor := range []string{"not-base64!", encodePageCursor(0), "cGFnZQ=="} {
+		_, err = decodePageCursor(cursor)
+		assert.Error(t, err, cursor)
+	}
+}
+
+func TestUnitParseParamsToolSavedList(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	params, err := ch.parseParamsToolSavedList(newRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", params.filter)
+	assert.Equal(t, defaultSavedLimit, params.limit)
+
+	params, err = ch.parseParamsToolSavedList(newRequest(map[string]any{"filter": "completed", "limit": 50}))
+	require.NoError(t, err)
+	assert.Equal(t, "completed", params.filter)
+	assert.Equal(t, 50, params.limit)
+
+	_, err = ch.parseParamsToolSavedList(newRequest(map[string]any{"filter": "starred"}))
+	require.ErrorContains(t, err, "invalid filter")
+
+	_, err = ch.parseParamsToolSavedList(newRequest(map[string]any{"limit": 500}))
+	require.Error(t, err)
+}
+
+func TestUnitCompleteOpenedConversation(t *testing.T) {
+	im := &slack.Channel{}
+	im.ID = "D1"
+	completeOpenedConversation(im, []string{"U1"}, "USELF")
+	assert.True(t, im.IsIM)
+	assert.Equal(t, "U1", im.User)
+	assert.Empty(t, im.Members)
+
+	mpim := &slack.Channel{}
+	mpim.ID = "G1"
+	completeOpenedConversation(mpim, []string{"U1", "U2"}, "USELF")
+	assert.True(t, mpim.IsMpIM)
+	assert.True(t, mpim.IsPrivate)
+	assert.Equal(t, []string{"U1", "U2", "USELF"}, mpim.Members)
+
+	known := &slack.Channel{}
+	known.Members = []string{"U1", "U2", "U3", "USELF"}
+	completeOpenedConversation(known, []string{"U1", "U2"}, "USELF")
+	assert.Equal(t, []string{"U1", "U2", "U3", "USELF"}, known.Members)
+}
+
+func TestUnitParseDateRange(t *testing.T) {
+	after, before, err := parseDateRange("", "")
+	require.NoError(t, err)
+	assert.True(t, after.IsZero())
+	assert.True(t, before.IsZero())
+
+	after, before, err = parseDateRange("2025-07-01", "2025-07-10")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-07-02", after.Format("2006-01-02"))
+	assert.Equal(t, "2025-07-10", before.Format("2006-01-02"))
+
+	_, _, err = parseDateRange("2025-07-01", "2025-07-02")
+	require.ErrorContains(t, err, "no days left")
+
+	_, _, err = parseDateRange("someday", "")
+	require.ErrorContains(t, err, "invalid 'after' date")
+}
+
+func newFakeConversationsHandler(api *fakeSlackAPI) *ConversationsHandler {
+	users := []slack.User{{ID: "U111", Name: "self"}, {ID: "U222", Name: "other"}}
+	channels := []provider.Channel{{ID: "C123", Name: "#general"}}
+	return NewConversationsHandler(newTestProvider(api, users, channels, zap.NewNop()), zap.NewNop())
+}
+
+func TestUnitModifyThreadReply(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	t.Setenv("SLACK_MCP_MODIFY_ANY_MESSAGE", "")
+
+	api := &fakeSlackAPI{
+		auth: slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"},
+		messages: map[string][]slack.Message{
+			"C123": {
+				{Msg: slack.Msg{Timestamp: "1752760800.000100", ThreadTimestamp: "1752760800.000100", User: "U222", Text: "parent"}},
+				{Msg: slack.Msg{Timestamp: "1752760800.000200", ThreadTimestamp: "1752760800.000100", User: "U222", Text: "other reply"}},
+				{Msg: slack.Msg{Timestamp: "1752760800.000300", ThreadTimestamp: "1752760800.000100", User: "U111", Text: "own reply"}},
+			},
+		},
+	}
+	ch := newFakeConversationsHandler(api)
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	t.Run("update own reply", func(t *testing.T) {
+		res, err := ch.ConversationsUpdateMessageHandler(context.Background(), newRequest(map[string]any{
+			"channel_id": "C123",
+			"timestamp":  "1752760800.000300",
+			"payload":    "edited",
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, []string{"1752760800.000300"}, api.updated)
+		assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "1752760800.000300")
+	})
+
+	t.Run("delete reply of another author", func(t *testing.T) {
+		_, err := ch.ConversationsDeleteMessageHandler(context.Background(), newRequest(map[string]any{
+			"channel_id": "C123",
+			"timestamp":  "1752760800.000200",
+		}))
+		require.ErrorContains(t, err, "not posted by the authenticated user")
+		assert.Empty(t, api.deleted)
+	})
+
+	t.Run("delete own reply", func(t *testing.T) {
+		_, err := ch.ConversationsDeleteMessageHandler(context.Background(), newRequest(map[string]any{
+			"channel_id": "C123",
+			"timestamp":  "1752760800.000300",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1752760800.000300"}, api.deleted)
+	})
+
+	t.Run("missing message", func(t *testing.T) {
+		_, err := ch.ConversationsDeleteMessageHandler(context.Background(), newRequest(map[string]any{
+			"channel_id": "C123",
+			"timestamp":  "1752760800.000900",
+		}))
+		require.Error(t, err)
+	})
+}
+
+func TestUnitConversationsContextThreadReply(t *testing.T) {
+	newAPI := func() *fakeSlackAPI {
+		return &fakeSlackAPI{
+			auth: slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"},
+			messages: map[string][]slack.Message{
+				"C123": {
+					{Msg: slack.Msg{Timestamp: "1752760700.000100", User: "U222", Text: "before"}},
+					{Msg: slack.Msg{Timestamp: "1752760800.000100", ThreadTimestamp: "1752760800.000100", User: "U222", Text: "parent"}},
+					{Msg: slack.Msg{Timestamp: "1752760800.000200", ThreadTimestamp: "1752760800.000100", User: "U111", Text: "first reply"}},
+					{Msg: slack.Msg{Timestamp: "1752760800.000300", ThreadTimestamp: "1752760800.000100", User: "U222", Text: "second reply"}},
+					{Msg: slack.Msg{Timestamp: "1752760900.000100", User: "U111", Text: "after"}},
+				},
+			},
+		}
+	}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+	positions := func(t *testing.T, res *mcp.CallToolResult) map[string]string {
+		rows, err := csv.NewReader(strings.NewReader(res.Content[0].(mcp.TextContent).Text)).ReadAll()
+		require.NoError(t, err)
+		got := map[string]string{}
+		for _, row := range rows[1:] {
+			got[row[1]] = row[0]
+		}
+		return got
+	}
+	permalink := "https://example.slack.com/archives/C123/p1752760800000300?thread_ts=1752760800.000100"
+
+	t.Run("permalink with thread_ts", func(t *testing.T) {
+		api := newAPI()
+		ch := newFakeConversationsHandler(api)
+		res, err := ch.ConversationsContextHandler(context.Background(), newRequest(map[string]any{"permalink": permalink}))
+		require.NoError(t, err)
+		assert.Equal(t, 1, api.repliesCalls)
+		assert.Equal(t, 2, api.historyCalls)
+		assert.Equal(t, map[string]string{
+			"1752760700.000100": "before",
+			"1752760800.000100": "parent",
+			"1752760800.000200": "reply",
+			"1752760800.000300": "message",
+			"1752760900.000100": "after",
+		}, positions(t, res))
+	})
+
+	t.Run("reply past replies_limit", func(t *testing.T) {
+		api := newAPI()
+		ch := newFakeConversationsHandler(api)
+		res, err := ch.ConversationsContextHandler(context.Background(), newRequest(map[string]any{
+			"permalink":     permalink,
+			"replies_limit": 2,
+			"context_size":  0,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, 0, api.historyCalls)
+		assert.Equal(t, map[string]string{
+			"1752760800.000100": "parent",
+			"1752760800.000200": "reply",
+			"1752760800.000300": "message",
+		}, positions(t, res))
+	})
+
+	t.Run("timestamp of a reply", func(t *testing.T) {
+		api := newAPI()
+		ch := newFakeConversationsHandler(api)
+		res, err := ch.ConversationsContextHandler(context.Background(), newRequest(map[string]any{
+			"channel_id":   "C123",
+			"timestamp":    "1752760800.000200",
+			"context_size": 0,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"1752760800.000100": "parent",
+			"1752760800.000200": "message",
+			"1752760800.000300": "reply",
+		}, positions(t, res))
+	})
+}
+
+func TestUnitOpenConversationPolicy(t *testing.T) {
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	t.Run("allowlist rejects before opening", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C123,D999")
+		api := &fakeSlackAPI{openedID: "D999"}
+		ch := newFakeConversationsHandler(api)
+
+		_, err := ch.ConversationsOpenHandler(context.Background(), newRequest(map[string]any{"users": "U222"}))
+		require.ErrorContains(t, err, "can not be opened")
+
+		_, err = ch.ConversationsAddMessageHandler(context.Background(), newRequest(map[string]any{"channel_id": "@other", "payload": "hi"}))
+		require.ErrorContains(t, err, "can not be opened")
+		assert.Equal(t, 0, api.openCalls)
+	})
+
+	t.Run("denylist rejects before caching", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!D999")
+		api := &fakeSlackAPI{openedID: "D999"}
+		ch := newFakeConversationsHandler(api)
+
+		_, err := ch.ConversationsOpenHandler(context.Background(), newRequest(map[string]any{"users": "U222"}))
+		require.ErrorContains(t, err, "not allowed")
+		_, ok := ch.apiProvider.ProvideChannelsMaps().Channels["D999"]
+		assert.False(t, ok)
+	})
+
+	t.Run("denylist allows other conversations", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "!D000")
+		api := &fakeSlackAPI{openedID: "D999"}
+		ch := newFakeConversationsHandler(api)
+
+		res, err := ch.ConversationsOpenHandler(context.Background(), newRequest(map[string]any{"users": "U222"}))
+		require.NoError(t, err)
+		assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "D999")
+		_, ok := ch.apiProvider.ProvideChannelsMaps().Channels["D999"]
+		assert.True(t, ok)
+	})
+}
+
+func TestUnitMessagePermalink(t *testing.T) {
+	t.Run("workspace URL", func(t *testing.T) {
+		api := &fakeSlackAPI{auth: slack.AuthTestResponse{URL: "https://example.slack.com/"}}
+		ch := newFakeConversationsHandler(api)
+
+		assert.Equal(t, "https://example.slack.com/archives/C123/p1752760800000200?thread_ts=1752760800.000100&cid=C123",
+			ch.messagePermalink(context.Background(), "C123", "1752760800.000200", "1752760800.000100"))
+		assert.Equal(t, 0, api.permalinkCalls)
+	})
+
+	t.Run("chat.getPermalink once per channel", func(t *testing.T) {
+		api := &fakeSlackAPI{}
+		ch := newFakeConversationsHandler(api)
+
+		assert.Equal(t, "https://org.enterprise.slack.com/archives/C123/p1752760800000100",
+			ch.messagePermalink(context.Background(), "C123", "1752760800.000100", ""))
+		assert.Equal(t, "https://org.enterprise.slack.com/archives/C123/p1752760800000200",
+			ch.messagePermalink(context.Background(), "C123", "1752760800.000200", ""))
+		assert.Equal(t, "https://org.enterprise.slack.com/archives/C456/p1752760800000300",
+			ch.messagePermalink(context.Background(), "C456", "1752760800.000300", ""))
+		assert.Equal(t, 2, api.permalinkCalls)
+	})
+}
+
+func TestUnitSavedItems(t *testing.T) {
+	api := &fakeSlackAPI{
+		auth: slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"},
+		messages: map[string][]slack.Message{
+			"C123": {
+				{Msg: slack.Msg{Timestamp: "1752760800.000100", ThreadTimestamp: "1752760800.000100", User: "U222", Text: "parent"}},
+				{Msg: slack.Msg{Timestamp: "1752760800.000200", ThreadTimestamp: "1752760800.000100", User: "U111", Text: "saved reply"}},
+				{Msg: slack.Msg{Timestamp: "1752760850.000100", User: "U111", Text: "unsaved"}},
+				{Msg: slack.Msg{Timestamp: "1752760900.000100", User: "U222", Text: "saved <@U111>"}},
+			},
+		},
+		saved: []edge.SavedItem{
+			{ItemType: "message", ItemID: "C123", TS: "1752760900.000100", State: "in_progress", DateCreated: 1752761000},
+			{ItemType: "message", ItemID: "C123", TS: "1752760800.000100", State: "in_progress", DateDue: 1752847200},
+			{ItemType: "message", ItemID: "C123", TS: "1752760800.000200", State: "in_progress"},
+			{ItemType: "channel", ItemID: "C123"},
+		},
+	}
+	ch := newFakeConversationsHandler(api)
+
+	items, err := ch.savedItems(context.Background(), &savedListParams{filter: "in_progress", limit: defaultSavedLimit})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, SavedItem{
+		ItemType:    "message",
+		ChannelID:   "C123",
+		ChannelName: "#general",
+		MsgID:       "1752760900.000100",
+		UserID:      "U222",
+		UserName:    "other",
+		Text:        "saved @self",
+		DateSaved:   "2025-07-17T14:03:20Z",
+		State:       "in_progress",
+		Permalink:   "https://example.slack.com/archives/C123/p1752760900000100",
+	}, items[0])
+	assert.Equal(t, "parent", items[1].Text)
+	assert.Equal(t, "2025-07-18T14:00:00Z", items[1].DateDue)
+	assert.Equal(t, "saved reply", items[2].Text)
+	assert.Equal(t, "self", items[2].UserName)
+	assert.Contains(t, items[2].Permalink, "thread_ts=1752760800.000100")
+
+	// one history call spans the channel, the reply is looked up on its own
+	assert.Equal(t, 2, api.historyCalls)
+	assert.Equal(t, 1, api.repliesCalls)
+}
+
+func TestUnitParseParamsToolUnreads(t *testing.T) {
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	params, err := ch.parseParamsToolUnreads(newRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Len(t, params.channelTypes, len(provider.AllChanTypes))
+	assert.False(t, params.mentionsOnly)
+	assert.False(t, params.includeMessages)
+	assert.Equal(t, defaultUnreadMessagesLimit, params.messagesLimit)
+
+	params, err = ch.parseParamsToolUnreads(newRequest(map[string]any{
+		"channel_types":    "im, mpim",
+		"mentions_only":    true,
+		"include_messages": true,
+		"messages_limit":   50,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"im": true, "mpim": true}, params.channelTypes)
+	assert.True(t, params.mentionsOnly)
+	assert.True(t, params.includeMessages)
+	assert.Equal(t, 50, params.messagesLimit)
+
+	_, err = ch.parseParamsToolUnreads(newRequest(map[string]any{"channel_types": "dm"}))
+	require.ErrorContains(t, err, "invalid channel type")
+
+	_, err = ch.parseParamsToolUnreads(newRequest(map[string]any{"messages_limit": 0}))
+	require.Error(t, err)
+	_, err = ch.parseParamsToolUnreads(newRequest(map[string]any{"messages_limit": 101}))
+	require.Error(t, err)
+}
+
+func TestUnitCollectUnreads(t *testing.T) {
+	snapshot := func(id, lastRead, latest string, mentions int, unread bool) edge.ChannelSnapshot {
+		var snap edge.ChannelSnapshot
+		data := fmt.Sprintf(`{"id": %q, "last_read": %q, "latest": %q, "mention_count": %d, "has_unreads": %t}`, id, lastRead, latest, mentions, unread)
+		require.NoError(t, json.Unmarshal([]byte(data), &snap))
+		return snap
+	}
+	counts := edge.ClientCountsResponse{
+		Channels: []edge.ChannelSnapshot{
+			snapshot("C1", "1752760000.000100", "1752760100.000100", 0, true),
+			snapshot("C2", "1752760000.000100", "1752760300.000100", 0, true),
+			snapshot("C3", "1752760000.000100", "1752760000.000100", 0, false),
+			snapshot("G1", "1752760000.000100", "1752760050.000100", 2, true),
+		},
+		IMs: []edge.ChannelSnapshot{
+			snapshot("D1", "", "1752760200.000100", 1, true),
+		},
+	}
+	channels := map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "#general"},
+		"G1": {ID: "G1", Name: "#secret", IsPrivate: true},
+		"D1": {ID: "D1", Name: "@alice", IsIM: true},
+	}
+	all := map[string]bool{"im": true, "mpim": true, provider.PubChanType: true, provider.PrivateChanType: true}
+
+	unreads, readMarks := collectUnreads(counts, channels, &unreadsParams{channelTypes: all})
+	var ids []string
+	for _, u := range unreads {
+		ids = append(ids, u.ChannelID)
+	}
+	assert.Equal(t, []string{"G1", "D1", "C2", "C1"}, ids)
+	assert.Equal(t, "#secret", unreads[0].ChannelName)
+	assert.Equal(t, provider.PrivateChanType, unreads[0].ChannelType)
+	assert.Equal(t, "C2", unreads[2].ChannelName)
+	assert.Equal(t, provider.PubChanType, unreads[2].ChannelType)
+	assert.Equal(t, "1752760000.000100", readMarks["C1"])
+	assert.Empty(t, readMarks["D1"])
+	assert.Empty(t, unreads[1].LastRead)
+
+	unreads, _ = collectUnreads(counts, channels, &unreadsParams{channelTypes: all, mentionsOnly: true})
+	assert.Len(t, unreads, 2)
+
+	unreads, _ = collectUnreads(counts, channels, &unreadsParams{channelTypes: map[string]bool{"im": true}})
+	require.Len(t, unreads, 1)
+	assert.Equal(t, "D1", unreads[0].ChannelID)
+}
+
+func TestUnitConversationsUnreadsWithoutReadMark(t *testing.T) {
+	var history []slack.Message
+	for i := 1; i <= 10; i++ {
+		history = append(history, slack.Message{Msg: slack.Msg{Timestamp: fmt.Sprintf("17527602%02d.000100", i), User: "U222", Text: "hi"}})
+	}
+	var counts edge.ClientCountsResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"ims": [{"id": "D1", "last_read": "0000000000.000000", "latest": "1752760210.000100", "has_unreads": true}]}`), &counts))
+
+	api := &fakeSlackAPI{
+		auth:     slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"},
+		messages: map[string][]slack.Message{"D1": history},
+		counts:   counts,
+	}
+	ch := newFakeConversationsHandler(api)
+
+	var req mcp.CallToolRequest
+	req.Params.Arguments = map[string]any{"include_messages": true}
+	res, err := ch.ConversationsUnreadsHandler(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+	rows, err := csv.NewReader(strings.NewReader(res.Content[1].(mcp.TextContent).Text)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, maxUnreadMessagesWithoutReadMark+1)
+	assert.Equal(t, "1752760210.000100", rows[1][0])
+}
+
+func TestUnitParseParamsToolPin(t *testing.T) {
+	t.Setenv("SLACK_MCP_PINS_TOOL", "true")
+	ch := &ConversationsHandler{logger: zap.NewNop()}
+	newRequest := func(args map[string]any) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return req
+	}
+
+	params, err := ch.parseParamsToolPin(newRequest(map[string]any{"channel_id": "C123", "timestamp": " 1752760800.123456 "}))
+	require.NoError(t, err)
+	assert.Equal(t, "C123", params.channel)
+	assert.Equal(t, "1752760800.123456", params.timestamp)
+
+	for _, ts := range []string{"", "yesterday", "1752760800", "p1752760800123456"} {
+		_, err = ch.parseParamsToolPin(newRequest(map[string]any{"channel_id": "C123", "timestamp": ts}))
+		require.ErrorContains(t, err, "timestamp must be in format", ts)
+	}
+}
+
+func TestUnitConversationsContextAfter(t *testing.T) {
+	const anchor = int64(1752760800)
+	newAPI := func(step time.Duration) *fakeSlackAPI {
+		msgs := []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1752760000.000100", User: "U222", Text: "before"}},
+			{Msg: slack.Msg{Timestamp: "1752760800.000100", User: "U222", Text: "anchor"}},
+		}
+		for i := 1; i <= 8; i++ {
+			ts := fmt.Sprintf("%d.000100", anchor+int64(i)*int64(step/time.Second))
+			msgs = append(msgs, slack.Message{Msg: slack.Msg{Timestamp: ts, User: "U111", Text: "later"}})
+		}
+		return &fakeSlackAPI{
+			auth:     slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"},
+			messages: map[string][]slack.Message{"C123": msgs},
+		}
+	}
+
+	for _, step := range []time.Duration{time.Minute, 24 * time.Hour} {
+		t.Run(step.String(), func(t *testing.T) {
+			ch := newFakeConversationsHandler(newAPI(step))
+			var req mcp.CallToolRequest
+			req.Params.Arguments = map[string]any{"channel_id": "C123", "timestamp": "1752760800.000100", "context_size": 3}
+			res, err := ch.ConversationsContextHandler(context.Background(), req)
+			require.NoError(t, err)
+
+			rows, err := csv.NewReader(strings.NewReader(res.Content[0].(mcp.TextContent).Text)).ReadAll()
+			require.NoError(t, err)
+			var after []string
+			for _, row := range rows[1:] {
+				if row[0] == "after" {
+					after = append(after, row[1])
+				}
+			}
+			var expected []string
+			for i := 1; i <= 3; i++ {
+				expected = append(expected, fmt.Sprintf("%d.000100", anchor+int64(i)*int64(step/time.Second)))
+			}
+			assert.Equal(t, expected, after)
+		})
+	}
+}
+
+func TestUnitFilesUpload(t *testing.T) {
+	t.Setenv("SLACK_MCP_UPLOAD_TOOL", "true")
+	api := &fakeSlackAPI{}
+	ch := newFakeConversationsHandler(api)
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"channel_id": "#general",
+		"thread_ts":  "1752760800.000100",
+		"filename":   `incident "prod".log`,
+		"title":      "Incident\nreport \\ \"prod\"",
+		"content":    "line one\nline two",
+	}
+	res, err := ch.FilesUploadHandler(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, api.uploaded, 1)
+	assert.Equal(t, "C123", api.uploaded[0].Channel)
+	assert.Equal(t, "1752760800.000100", api.uploaded[0].ThreadTimestamp)
+
+	var file UploadedFile
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &file))
+	assert.Equal(t, UploadedFile{
+		FileID:    "F123",
+		Filename:  `incident "prod".log`,
+		Title:     "Incident\nreport \\ \"prod\"",
+		Size:      len("line one\nline two"),
+		ChannelID: "C123",
+		ThreadTs:  "1752760800.000100",
+	}, file)
+	assert.Equal(t, file, res.StructuredContent)
+}
+
+func TestUnitReactionsListCursorWithoutMatches(t *testing.T) {
+	api := &fakeSlackAPI{auth: slack.AuthTestResponse{UserID: "U111", URL: "https://example.slack.com/"}}
+	for i := 0; i < maxReactionsListPages+2; i++ {
+		ts := fmt.Sprintf("1577872800.%06d", i) // 2020-01-01
+		if i == maxReactionsListPages+1 {
+			ts = "1752760800.000100"
+		}
+		api.reactions = append(api.reactions, slack.ReactedItem{
+			Item:      slack.Item{Type: "message", Channel: "C123", Message: &slack.Message{Msg: slack.Msg{Timestamp: ts, User: "U222", Text: "item " + strconv.Itoa(i)}}},
+			Reactions: []slack.ItemReaction{{Name: "eyes", Count: 1, Users: []string{"U111"}}},
+		})
+	}
+	ch := newFakeConversationsHandler(api)
+
+	args := map[string]any{"limit": 1, "filter_date_after": "2025-01-01", "format": "json"}
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	res, err := ch.ReactionsListHandler(context.Background(), req)
+	require.NoError(t, err)
+	var page RowsResult[ReactedMessage]
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &page))
+	assert.Empty(t, page.Items)
+	assert.Equal(t, encodePageCursor(maxReactionsListPages+1), page.NextCursor)
+
+	args["format"] = "csv"
+	res, err = ch.ReactionsListHandler(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(res.Content[0].(mcp.TextContent).Text, ","+encodePageCursor(maxReactionsListPages+1)+"\n"))
+
+	args["cursor"] = page.NextCursor
+	args["format"] = "json"
+	res, err = ch.ReactionsListHandler(context.Background(), req)
+	require.NoError(t, err)
+	page = RowsResult[ReactedMessage]{}
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &page))
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "1752760800.000100", page.Items[0].MsgID)
+	assert.Empty(t, page.NextCursor)
+}
@@ -0,0 +1,406 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultEmojiLimit = 100
+	maxEmojiLimit     = 1000
+	maxEmojiSuggested = 5
+
+	// emojiRefreshInterval limits how often an unknown emoji name triggers a
+	// refresh of the cached catalogue, e.g. after a custom emoji was added
+	emojiRefreshInterval = 5 * time.Minute
+)
+
+type Emoji struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	AliasFor string `json:"aliasFor"`
+	URL      string `json:"url"`
+	Cursor   string `json:"cursor,omitempty"`
+}
+
+type emojiListParams struct {
+	query           string
+	includeStandard bool
+	cursor          string
+	limit           int
+}
+
+type EmojiHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewEmojiHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *EmojiHandler {
+	return &EmojiHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// EmojiListHandler returns custom emoji of the workspace with their aliases
+// and, optionally, names of the standard emoji as CSV
+func (eh *EmojiHandler) EmojiListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eh.logger.Debug("EmojiListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := eh.apiProvider.IsReady(); !ready {
+		eh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params := parseParamsToolEmojiList(request)
+
+	if err := ensureEmoji(ctx, eh.apiProvider); err != nil {
+		eh.logger.Error("Failed to fetch emoji", zap.Error(err))
+		return nil, err
+	}
+
+	emoji := filterEmoji(eh.apiProvider.ProvideEmojiMap(), params.query, params.includeStandard)
+	paged, nextcur := paginateEmoji(emoji, params.cursor, params.limit)
+	if len(paged) > 0 && nextcur != "" {
+		paged[len(paged)-1].Cursor = nextcur
+	}
+
+	return marshalRows(request, paged)
+}
+
+func parseParamsToolEmojiList(request mcp.CallToolRequest) *emojiListParams {
+	params := &emojiListParams{
+		query:           strings.ToLower(strings.Trim(strings.TrimSpace(request.GetString("query", "")), ":")),
+		includeStandard: request.GetBool("include_standard", false),
+		cursor:          request.GetString("cursor", ""),
+		limit:           request.GetInt("limit", defaultEmojiLimit),
+	}
+	if params.limit <= 0 {
+		params.limit = defaultEmojiLimit
+	}
+	if params.limit > maxEmojiLimit {
+		params.limit = maxEmojiLimit
+	}
+	return params
+}
+
+// filterEmoji flattens the catalogue to CSV rows sorted by name, keeping only
+// emoji whose name or alias target contains query
+func filterEmoji(cache *provider.EmojiCache, query string, includeStandard bool) []Emoji {
+	var result []Emoji
+	for name, value := range cache.Custom {
+		row := Emoji{Name: name, Type: "custom", URL: value}
+		if target, ok := strings.CutPrefix(value, "alias:"); ok {
+			row.Type = "alias"
+			row.AliasFor = target
+			row.URL = ""
+			if u, ok := cache.Custom[target]; ok && !strings.HasPrefix(u, "alias:") {
+				row.URL = u
+			}
+		}
+		if query != "" && !strings.Contains(row.Name, query) && !strings.Contains(row.AliasFor, query) {
+			continue
+		}
+		result = append(result, row)
+	}
+
+	if includeStandard {
+		for name := range cache.Standard {
+			if query != "" && !strings.Contains(name, query) {
+				continue
+			}
+			result = append(result, Emoji{Name: name, Type: "standard"})
+		}
+		for alias, target := range standardEmojiAliases {
+			if _, ok := cache.Standard[alias]; ok {
+				continue
+			}
+			if query != "" && !strings.Contains(alias, query) && !strings.Contains(target, query) {
+				continue
+			}
+			result = append(result, Emoji{Name: alias, Type: "standard", AliasFor: target})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func paginateEmoji(emoji []Emoji, cursor string, limit int) ([]Emoji, string) {
+	startIndex := 0
+	if cursor != "" {
+		if decoded, err := base64.StdEncoding.DecodeString(cursor); err == nil {
+			lastName := string(decoded)
+			startIndex = sort.Search(len(emoji), func(i int) bool {
+				return emoji[i].Name > lastName
+			})
+		}
+	}
+
+	endIndex := startIndex + limit
+	if endIndex > len(emoji) {
+		endIndex = len(emoji)
+	}
+
+	var nextCursor string
+	if endIndex < len(emoji) {
+		nextCursor = base64.StdEncoding.EncodeToString([]byte(emoji[endIndex-1].Name))
+	}
+	return emoji[startIndex:endIndex], nextCursor
+}
+
+// ensureEmoji caches the emoji catalogue on first use
+func ensureEmoji(ctx context.Context, apiProvider *provider.ApiProvider) error {
+	if !apiProvider.EmojiRefreshedAt().IsZero() {
+		return nil
+	}
+	if err := apiProvider.RefreshEmoji(ctx); err != nil {
+		return fmt.Errorf("failed to fetch emoji: %v", err)
+	}
+	return nil
+}
+
+// validateEmoji checks a normalized emoji name against the cached catalogue,
+// refreshing it once in a while for recently added custom emoji. If the
+// catalogue is not available the name is left for Slack to judge.
+func validateEmoji(ctx context.Context, apiProvider *provider.ApiProvider, logger *zap.Logger, name string) error {
+	if err := ensureEmoji(ctx, apiProvider); err != nil {
+		logger.Warn("Emoji catalogue not available, skipping validation", zap.Error(err))
+		return nil
+	}
+
+	cache := apiProvider.ProvideEmojiMap()
+	if len(cache.Standard) == 0 || isKnownEmoji(name, cache) {
+		return nil
+	}
+
+	if time.Since(apiProvider.EmojiRefreshedAt()) > emojiRefreshInterval {
+		if err := apiProvider.RefreshEmoji(ctx); err == nil {
+			cache = apiProvider.ProvideEmojiMap()
+			if isKnownEmoji(name, cache) {
+				return nil
+			}
+		}
+	}
+
+	return unknownEmojiError(name, cache)
+}
+
+func isKnownEmoji(name string, cache *provider.EmojiCache) bool {
+	base, _, _ := strings.Cut(name, "::")
+	if _, ok := cache.Custom[base]; ok {
+		return true
+	}
+	if _, ok := cache.Standard[base]; ok {
+		return true
+	}
+	_, ok := standardEmojiAliases[base]
+	return ok
+}
+
+func unknownEmojiError(name string, cache *provider.EmojiCache) error {
+	suggestions := suggestEmoji(name, cache)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("emoji %q does not exist in this workspace, use emoji_list to look up custom emoji names", name)
+	}
+	for i, s := range suggestions {
+		suggestions[i] = ":" + s + ":"
+	}
+	return fmt.Errorf("emoji %q does not exist in this workspace, did you mean %s?", name, strings.Join(suggestions, ", "))
+}
+
+// emojiNameError replaces Slack's invalid_name error with suggestions from the
+// catalogue, other errors are returned as is
+func emojiNameError(ctx context.Context, apiProvider *provider.ApiProvider, name string, err error) error {
+	var slackErr slack.SlackErrorResponse
+	if !errors.As(err, &slackErr) || slackErr.Err != "invalid_name" {
+		return err
+	}
+	if ensureEmoji(ctx, apiProvider) != nil {
+		return err
+	}
+	return unknownEmojiError(name, apiProvider.ProvideEmojiMap())
+}
+
+// suggestEmoji returns known emoji names close to name, names containing it or
+// contained in it first, then by edit distance
+func suggestEmoji(name string, cache *provider.EmojiCache) []string {
+	base, _, _ := strings.Cut(name, "::")
+	maxDistance := max(2, utf8.RuneCountInString(base)/3)
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	consider := func(n string) {
+		d := levenshtein(base, n)
+		switch {
+		case len(base) >= 3 && (strings.Contains(n, base) || (len(n) >= 3 && strings.Contains(base, n))):
+			d = min(d, 1)
+		case d > maxDistance:
+			return
+		}
+		candidates = append(candidates, candidate{name: n, distance: d})
+	}
+	for n := range cache.Custom {
+		consider(n)
+	}
+	for n := range cache.Standard {
+		consider(n)
+	}
+	for n := range standardEmojiAliases {
+		if _, ok := cache.Standard[n]; !ok {
+			consider(n)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var result []string
+	for _, c := range candidates {
+		if len(result) == maxEmojiSuggested {
+			break
+		}
+		result = append(result, c.name)
+	}
+	return result
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// normalizeEmojiName turns :name:, name, unicode emoji and skin tone variants
+// into the name expected by reactions.add, e.g. "👍🏽" becomes
+// "+1::skin-tone-4"
+func normalizeEmojiName(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("emoji is required")
+	}
+
+	if r, _ := utf8.DecodeRuneInString(raw); r >= utf8.RuneSelf {
+		return unicodeEmojiName(raw)
+	}
+
+	name := strings.ToLower(strings.Trim(raw, ":"))
+	if name == "" {
+		return "", errors.New("emoji is required")
+	}
+	if strings.ContainsAny(name, " \t") {
+		return "", fmt.Errorf("invalid emoji name %q, pass a single emoji name such as thumbsup or :tada:", raw)
+	}
+	return name, nil
+}
+
+// unicodeEmojiName maps a unicode emoji to its Slack name
+func unicodeEmojiName(raw string) (string, error) {
+	var (
+		b        strings.Builder
+		skinTone string
+	)
+	for _, r := range raw {
+		switch {
+		case r == '\uFE0F':
+			// Variation selector, e.g. in ❤️
+		case r >= 0x1F3FB && r <= 0x1F3FF:
+			skinTone = fmt.Sprintf("::skin-tone-%d", r-0x1F3FB+2)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	name, ok := unicodeEmoji[b.String()]
+	if !ok {
+		return "", fmt.Errorf("unsupported unicode emoji %q, pass its Slack name instead, e.g. :tada:", raw)
+	}
+	return name + skinTone, nil
+}
+
+// unicodeEmoji maps frequently used unicode emoji to their Slack names
+var unicodeEmoji = map[string]string{
+	"👍": "+1", "👎": "-1", "👌": "ok_hand", "👏": "clap", "🙌": "raised_hands",
+	"🙏": "pray", "💪": "muscle", "👋": "wave", "✌": "v", "🤞": "crossed_fingers",
+	"🤝": "handshake", "👀": "eyes", "👆": "point_up_2", "👉": "point_right", "👈": "point_left",
+	"👇": "point_down", "✋": "raised_hand", "🤌": "pinched_fingers", "🫡": "saluting_face", "🤷": "shrug",
+	"🤦": "face_palm", "❤": "heart", "💔": "broken_heart", "💚": "green_heart", "💙": "blue_heart",
+	"💜": "purple_heart", "💛": "yellow_heart", "🧡": "orange_heart", "🖤": "black_heart", "🤍": "white_heart",
+	"😀": "grinning", "😃": "smiley", "😄": "smile", "😁": "grin", "😆": "laughing",
+	"😅": "sweat_smile", "🤣": "rolling_on_the_floor_laughing", "😂": "joy", "🙂": "slightly_smiling_face", "🙃": "upside_down_face",
+	"😉": "wink", "😊": "blush", "😇": "innocent", "😍": "heart_eyes", "🤩": "star-struck",
+	"🤔": "thinking_face", "🤗": "hugging_face", "🤓": "nerd_face", "😎": "sunglasses", "🥳": "partying_face",
+	"😐": "neutral_face", "🙄": "face_with_rolling_eyes", "😬": "grimacing", "😕": "confused", "😞": "disappointed",
+	"😢": "cry", "😭": "sob", "😮": "open_mouth", "😱": "scream", "🤯": "exploding_head",
+	"😡": "rage", "😤": "triumph", "🥺": "pleading_face", "😴": "sleeping", "🫠": "melting_face",
+	"🙈": "see_no_evil", "💀": "skull", "👻": "ghost", "🤖": "robot_face", "💩": "hankey",
+	"🎉": "tada", "🎊": "confetti_ball", "🏆": "trophy", "🎯": "dart", "🚀": "rocket",
+	"🔥": "fire", "💯": "100", "✨": "sparkles", "⭐": "star", "🌟": "star2",
+	"💡": "bulb", "✅": "white_check_mark", "✔": "heavy_check_mark", "☑": "ballot_box_with_check", "❌": "x",
+	"➕": "heavy_plus_sign", "❓": "question", "❗": "exclamation", "⚠": "warning", "🚨": "rotating_light",
+	"🛑": "octagonal_sign", "🚧": "construction", "⏳": "hourglass_flowing_sand", "⌛": "hourglass", "⏰": "alarm_clock",
+	"🐛": "bug", "🔧": "wrench", "🔒": "lock", "🔗": "link", "📌": "pushpin",
+	"📝": "memo", "📣": "mega", "📈": "chart_with_upwards_trend", "📉": "chart_with_downwards_trend", "🧵": "thread",
+	"💬": "speech_balloon", "🆗": "ok", "🆒": "cool", "🆕": "new", "🟢": "large_green_circle",
+	"🟡": "large_yellow_circle", "🔴": "red_circle", "☕": "coffee", "🍕": "pizza", "🍺": "beer",
+	"🎂": "birthday",
+}
+
+// standardEmojiAliases maps well-known alternative names of standard emoji,
+// which emoji.list categories do not include, to their canonical names
+var standardEmojiAliases = map[string]string{
+	"thumbsup":               "+1",
+	"thumbsdown":             "-1",
+	"poop":                   "hankey",
+	"shit":                   "hankey",
+	"satisfied":              "laughing",
+	"facepunch":              "punch",
+	"hand":                   "raised_hand",
+	"boom":                   "collision",
+	"pencil":                 "memo",
+	"heavy_exclamation_mark": "exclamation",
+	"runner":                 "running",
+	"sign_of_the_horns":      "the_horns",
+	"knife":                  "hocho",
+	"bee":                    "honeybee",
+	"red_car":                "car",
+	"shirt":                  "tshirt",
+	"book":                   "open_book",
+	"phone":                  "telephone",
+	"sailboat":               "boat",
+}
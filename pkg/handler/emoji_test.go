@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitNormalizeEmojiName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"plain name", "tada", "tada", false},
+		{"colons", ":+1:", "+1", false},
+		{"uppercase", ":Party_Parrot:", "party_parrot", false},
+		{"skin tone name", ":+1::skin-tone-3:", "+1::skin-tone-3", false},
+		{"unicode", "🚀", "rocket", false},
+		{"unicode with variation selector", "❤️", "heart", false},
+		{"unicode with skin tone", "👍🏽", "+1::skin-tone-4", false},
+		{"unknown unicode", "🦩", "", true},
+		{"empty", "  ", "", true},
+		{"only colons", "::", "", true},
+		{"sentence", "thumbs up", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeEmojiName(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnitUnknownEmojiError(t *testing.T) {
+	cache := &provider.EmojiCache{
+		Custom: map[string]string{
+			"party-parrot": "https://emoji.slack-edge.com/T1/party-parrot/abc.gif",
+			"partyparrot":  "alias:party-parrot",
+			"shipit":       "https://emoji.slack-edge.com/T1/shipit/def.png",
+		},
+		Standard: map[string]struct{}{
+			"+1":   {},
+			"tada": {},
+		},
+	}
+
+	assert.True(t, isKnownEmoji("partyparrot", cache))
+	assert.True(t, isKnownEmoji("+1::skin-tone-2", cache))
+	assert.True(t, isKnownEmoji("thumbsup", cache))
+	assert.False(t, isKnownEmoji("party_parrot", cache))
+
+	err := unknownEmojiError("party_parrot", cache)
+	assert.EqualError(t, err, `emoji "party_parrot" does not exist in this workspace, did you mean :party-parrot:, :partyparrot:?`)
+
+	err = unknownEmojiError("ship_it", cache)
+	assert.EqualError(t, err, `emoji "ship_it" does not exist in this workspace, did you mean :shipit:?`)
+
+	err = unknownEmojiError("xyzzy", cache)
+	assert.EqualError(t, err, `emoji "xyzzy" does not exist in this workspace, use emoji_list to look up custom emoji names`)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	_ "unsafe" // for go:linkname
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// newTestProvider returns a provider backed by client with users and channels
+// cached and reported ready, the constructor is not part of the provider API
+//
+//go:linkname newTestProvider github.com/korotovsky/slack-mcp-server/pkg/provider.newWithClient
+func newTestProvider(client provider.SlackAPI, users []slack.User, channels []provider.Channel, logger *zap.Logger) *provider.ApiProvider
+
+// fakeSlackAPI serves messages of channels from memory, mimicking how Slack
+// filters conversations.history and conversations.replies. Methods which are
+// not implemented panic through the embedded nil interface
+type fakeSlackAPI struct {
+	provider.SlackAPI
+
+	auth     slack.AuthTestResponse
+	messages map[string][]slack.Message
+
+	openedID  string
+	saved     []edge.SavedItem
+	counts    edge.ClientCountsResponse
+	reactions []slack.ReactedItem
+
+	historyCalls   int
+	repliesCalls   int
+	openCalls      int
+	permalinkCalls int
+	updated        []string
+	deleted        []string
+	uploaded       []slack.UploadFileV2Parameters
+}
+
+func (f *fakeSlackAPI) AuthTest() (*slack.AuthTestResponse, error) {
+	auth := f.auth
+	return &auth, nil
+}
+
+func (f *fakeSlackAPI) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
+	return f.AuthTest()
+}
+
+// GetConversationHistoryContext returns top-level messages of the channel
+// within oldest and latest, newest first and paged by cursor
+func (f *fakeSlackAPI) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	f.historyCalls++
+	var msgs []slack.Message
+	for _, msg := range f.messages[params.ChannelID] {
+		if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+			continue
+		}
+		if inRange(msg.Timestamp, params.Oldest, params.Latest, params.Inclusive) {
+			msgs = append(msgs, msg)
+		}
+	}
+	slices.SortFunc(msgs, func(a, b slack.Message) int { return strings.Compare(b.Timestamp, a.Timestamp) })
+
+	// the cursor is the offset of the next page
+	offset, _ := strconv.Atoi(params.Cursor)
+	msgs = msgs[min(offset, len(msgs)):]
+	resp := &slack.GetConversationHistoryResponse{
+		SlackResponse: slack.SlackResponse{Ok: true},
+		Messages:      limitMessages(msgs, params.Limit),
+	}
+	if len(resp.Messages) < len(msgs) {
+		resp.HasMore = true
+		resp.ResponseMetaData.NextCursor = strconv.Itoa(offset + len(resp.Messages))
+	}
+	return resp, nil
+}
+
+// GetConversationRepliesContext returns the parent of the thread first and
+// then its replies within oldest and latest, oldest first
+func (f *fakeSlackAPI) GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	f.repliesCalls++
+	var parent *slack.Message
+	var replies []slack.Message
+	for _, msg := range f.messages[params.ChannelID] {
+		switch {
+		case msg.Timestamp == params.Timestamp:
+			parent = &msg
+		case msg.ThreadTimestamp == params.Timestamp && inRange(msg.Timestamp, params.Oldest, params.Latest, params.Inclusive):
+			replies = append(replies, msg)
+		}
+	}
+	if parent == nil {
+		return nil, false, "", errors.New("thread_not_found")
+	}
+	slices.SortFunc(replies, func(a, b slack.Message) int { return strings.Compare(a.Timestamp, b.Timestamp) })
+	return limitMessages(append([]slack.Message{*parent}, replies...), params.Limit), false, "", nil
+}
+
+func (f *fakeSlackAPI) UpdateMessageContext(ctx context.Context, channel, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	f.updated = append(f.updated, timestamp)
+	return channel, timestamp, "", nil
+}
+
+func (f *fakeSlackAPI) DeleteMessageContext(ctx context.Context, channel, timestamp string) (string, string, error) {
+	f.deleted = append(f.deleted, timestamp)
+	return channel, timestamp, nil
+}
+
+func (f *fakeSlackAPI) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	f.uploaded = append(f.uploaded, params)
+	return &slack.FileSummary{ID: "F123", Title: params.Title}, nil
+}
+
+func (f *fakeSlackAPI) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	f.openCalls++
+	return &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: f.openedID}}}, false, false, nil
+}
+
+// ListReactionsContext returns the reacted items in pages of params.Count
+func (f *fakeSlackAPI) ListReactionsContext(ctx context.Context, params slack.ListReactionsParameters) ([]slack.ReactedItem, *slack.Paging, error) {
+	pages := (len(f.reactions) + params.Count - 1) / params.Count
+	start := min((params.Page-1)*params.Count, len(f.reactions))
+	end := min(start+params.Count, len(f.reactions))
+	return f.reactions[start:end], &slack.Paging{Count: params.Count, Total: len(f.reactions), Page: params.Page, Pages: pages}, nil
+}
+
+func (f *fakeSlackAPI) ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error) {
+	return f.counts, nil
+}
+
+func (f *fakeSlackAPI) SavedList(ctx context.Context, filter, cursor string, limit int) (*edge.SavedListResponse, error) {
+	return &edge.SavedListResponse{SavedItems: f.saved}, nil
+}
+
+// GetPermalinkContext returns links of an Enterprise Grid organization
+func (f *fakeSlackAPI) GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error) {
+	f.permalinkCalls++
+	return "https://org.enterprise.slack.com/archives/" + params.Channel + "/p" + strings.ReplaceAll(params.Ts, ".", ""), nil
+}
+
+// inRange checks ts against oldest and latest, which are exclusive unless
+// inclusive is set
+func inRange(ts, oldest, latest string, inclusive bool) bool {
+	if ts == oldest || ts == latest {
+		return inclusive
+	}
+	return (oldest == "" || ts > oldest) && (latest == "" || ts < latest)
+}
+
+// limitMessages truncates msgs to limit, zero is Slack's default of 100
+func limitMessages(msgs []slack.Message, limit int) []slack.Message {
+	if limit <= 0 {
+		limit = 100
+	}
+	if len(msgs) > limit {
+		return msgs[:limit]
+	}
+	return msgs
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Output formats of tool results and resources. CSV is compact for models
+// to read, JSON is meant for clients which parse the results.
+const (
+	outputFormatCSV  = "csv"
+	outputFormatJSON = "json"
+)
+
+// relatedRows is a second table of a result, e.g. the members of a channel,
+// it becomes another CSV content or another key of the JSON object
+type relatedRows struct {
+	name string
+	rows any
+}
+
+// RowsResult is the JSON form and the structured content of tools returning
+// rows, NextCursor replaces the cursor of the last CSV row
+type RowsResult[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// jsonStatus is the JSON form of results which only report success
+type jsonStatus struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+// defaultOutputFormat returns the server wide format from
+// SLACK_MCP_OUTPUT_FORMAT, CSV unless set to json
+func defaultOutputFormat() string {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("SLACK_MCP_OUTPUT_FORMAT")), outputFormatJSON) {
+		return outputFormatJSON
+	}
+	return outputFormatCSV
+}
+
+// ResourceMIMEType returns the MIME type of resources, which are served in
+// the server wide format
+func ResourceMIMEType() string {
+	if defaultOutputFormat() == outputFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// outputFormat returns the format requested by the "format" argument of a
+// tool call, falling back to the server default
+func outputFormat(request mcp.CallToolRequest) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(request.GetString("format", "")))
+	switch format {
+	case "":
+		return defaultOutputFormat(), nil
+	case outputFormatCSV, outputFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("format must be one of %s or %s, got %q", outputFormatCSV, outputFormatJSON, format)
+	}
+}
+
+// ValidateOutputFormat rejects an unknown "format" argument before a tool
+// runs, so write tools fail before they change anything
+func ValidateOutputFormat(request mcp.CallToolRequest) error {
+	_, err := outputFormat(request)
+	return err
+}
+
+// marshalRows renders a slice of rows in the requested format. Handlers keep
+// the cursor of the next page in the Cursor column of the last row as CSV
+// expects it, JSON moves it to a top-level next_cursor
+func marshalRows(request mcp.CallToolRequest, rows any, related ...relatedRows) (*mcp.CallToolResult, error) {
+	format, err := outputFormat(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == outputFormatCSV {
+		csvBytes, err := gocsv.MarshalBytes(rows)
+		if err != nil {
+			return nil, err
+		}
+		result := &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.NewTextContent(string(csvBytes))},
+		}
+		for _, r := range related {
+			relatedBytes, err := gocsv.MarshalBytes(r.rows)
+			if err != nil {
+				return nil, err
+			}
+			result.Content = append(result.Content, mcp.NewTextContent(string(relatedBytes)))
+		}
+		return result, nil
+	}
+
+	items, nextCursor := rowsWithoutCursor(rows)
+	object := map[string]any{"items": items}
+	if nextCursor != "" {
+		object["next_cursor"] = nextCursor
+	}
+	for _, r := range related {
+		relatedItems, _ := rowsWithoutCursor(r.rows)
+		object[r.name] = relatedItems
+	}
+
+	jsonBytes, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// marshalStructuredRows renders rows like marshalRows and adds them as
+// structured content for tools declaring a RowsResult output schema
+func marshalStructuredRows[T any](request mcp.CallToolRequest, rows []T) (*mcp.CallToolResult, error) {
+	result, err := marshalRows(request, rows)
+	if err != nil {
+		return nil, err
+	}
+	items, nextCursor := rowsWithoutCursor(rows)
+	result.StructuredContent = RowsResult[T]{
+		Items:      items.([]T),
+		NextCursor: nextCursor,
+	}
+	return result, nil
+}
+
+// marshalStatus renders the message of a tool which only reports success
+func marshalStatus(request mcp.CallToolRequest, message string) (*mcp.CallToolResult, error) {
+	format, err := outputFormat(request)
+	if err != nil {
+		return nil, err
+	}
+	if format == outputFormatCSV {
+		return mcp.NewToolResultText(message), nil
+	}
+
+	jsonBytes, err := json.Marshal(jsonStatus{OK: true, Message: message})
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// marshalResource renders the rows of a resource in the server default
+// format, resources have no arguments to choose one
+func marshalResource(uri string, rows any) ([]mcp.ResourceContents, error) {
+	if defaultOutputFormat() == outputFormatCSV {
+		csvBytes, err := gocsv.MarshalBytes(rows)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: ResourceMIMEType(),
+				Text:     string(csvBytes),
+			},
+		}, nil
+	}
+
+	items, _ := rowsWithoutCursor(rows)
+	jsonBytes, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: ResourceMIMEType(),
+			Text:     string(jsonBytes),
+		},
+	}, nil
+}
+
+// rowsWithoutCursor returns the rows, a slice or a pointer to one, with the
+// cursor taken out of the Cursor field of the last row. A last row carrying
+// only the cursor, which handlers add when no row matched, is dropped. A nil
+// slice becomes an empty one so JSON has an array
+func rowsWithoutCursor(rows any) (any, string) {
+	v := reflect.ValueOf(rows)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return rows, ""
+	}
+	if v.Len() == 0 {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface(), ""
+	}
+
+	items := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(items, v)
+
+	last := items.Index(items.Len() - 1)
+	if last.Kind() != reflect.Struct {
+		return items.Interface(), ""
+	}
+	field := last.FieldByName("Cursor")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return items.Interface(), ""
+	}
+	cursor := field.String()
+	field.SetString("")
+	if cursor != "" && last.IsZero() {
+		items = items.Slice(0, items.Len()-1)
+	}
+	return items.Interface(), cursor
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMarshalRows(t *testing.T) {
+	newRequest := func(format string) mcp.CallToolRequest {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = map[string]any{"format": format}
+		return req
+	}
+	messages := []Message{
+		{MsgID: "1752760800.000100", UserName: "alice", ThreadTs: "1752760700.000100", Text: "hello, world", Reactions: "eyes:2", ReactionList: []MessageReaction{{Name: "eyes", Count: 2}}},
+		{MsgID: "1752760900.000100", FileCount: 1, AttachmentIDs: "F1", Files: []MessageFile{{ID: "F1", Name: "a.png", Size: 10}}, Cursor: "next"},
+	}
+
+	result, err := marshalRows(newRequest("csv"), messages)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	csvText := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, csvText, "MsgID,UserID,UserName,RealName,Channel,ThreadTs,Text,Time,Reactions,BotName,FileCount,AttachmentIDs,HasMedia,Permalink,Cursor\n")
+	assert.Contains(t, csvText, `"hello, world"`)
+	assert.Contains(t, csvText, ",next\n")
+
+	result, err = marshalRows(newRequest("json"), messages, relatedRows{name: "members", rows: []User(nil)})
+	require.NoError(t, err)
+	var decoded struct {
+		Items      []map[string]any `json:"items"`
+		NextCursor string           `json:"next_cursor"`
+		Members    []User           `json:"members"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &decoded))
+	require.Len(t, decoded.Items, 2)
+	assert.Equal(t, "next", decoded.NextCursor)
+	assert.NotNil(t, decoded.Members)
+	assert.NotContains(t, decoded.Items[1], "cursor")
+	assert.Equal(t, "alice", decoded.Items[0]["userName"])
+	assert.Equal(t, "1752760700.000100", decoded.Items[0]["threadTs"])
+	assert.Equal(t, []any{map[string]any{"name": "eyes", "count": float64(2)}}, decoded.Items[0]["reactions"])
+	assert.Equal(t, float64(1), decoded.Items[1]["fileCount"])
+	assert.Equal(t, "next", messages[1].Cursor, "rows of the caller must not change")
+
+	_, err = marshalRows(newRequest("xml"), messages)
+	assert.ErrorContains(t, err, "format must be one of")
+}
+
+func TestUnitDefaultOutputFormat(t *testing.T) {
+	t.Setenv("SLACK_MCP_OUTPUT_FORMAT", "")
+	assert.Equal(t, outputFormatCSV, defaultOutputFormat())
+	assert.Equal(t, "text/csv", ResourceMIMEType())
+
+	t.Setenv("SLACK_MCP_OUTPUT_FORMAT", "JSON")
+	assert.Equal(t, outputFormatJSON, defaultOutputFormat())
+	assert.Equal(t, "application/json", ResourceMIMEType())
+
+	var req mcp.CallToolRequest
+	format, err := outputFormat(req)
+	require.NoError(t, err)
+	assert.Equal(t, outputFormatJSON, format)
+
+	result, err := marshalStatus(req, "Successfully pinned message")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok": true, "message": "Successfully pinned message"}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestUnitMarshalStructuredRows(t *testing.T) {
+	var req mcp.CallToolRequest
+	channels := []Channel{{ID: "C1", Name: "#general", MemberCount: 3}, {ID: "C2", Name: "#random", Cursor: "next"}}
+
+	result, err := marshalStructuredRows(req, channels)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "ID,Name,Topic,Purpose,MemberCount,IsArchived,Cursor\n")
+
+	structured, ok := result.StructuredContent.(RowsResult[Channel])
+	require.True(t, ok)
+	assert.Equal(t, "next", structured.NextCursor)
+	require.Len(t, structured.Items, 2)
+	assert.Equal(t, "", structured.Items[1].Cursor)
+
+	tool := mcp.NewTool("channels_list", mcp.WithOutputSchema[RowsResult[Channel]]())
+	schema, err := json.Marshal(tool.OutputSchema)
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), `"memberCount":{"type":"integer"}`)
+	assert.Contains(t, string(schema), `"required":["items"]`)
+}
@@ -0,0 +1,592 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUsersLimit   = 100
+	maxUsersLimit       = 999
+	maxPresenceUsers    = 50
+	maxStatusTextLength = 100 // users.profile.set limit
+)
+
+type UserProfile struct {
+	UserID       string `json:"userID"`
+	UserName     string `json:"userName"`
+	RealName     string `json:"realName"`
+	DisplayName  string `json:"displayName"`
+	Email        string `json:"email"`
+	Title        string `json:"title"`
+	TZ           string `json:"tz"`
+	StatusText   string `json:"statusText"`
+	StatusEmoji  string `json:"statusEmoji"`
+	IsBot        bool   `json:"isBot"`
+	IsRestricted bool   `json:"isRestricted"`
+	Deleted      bool   `json:"deleted"`
+	Cursor       string `json:"cursor,omitempty"`
+}
+
+type Usergroup struct {
+	ID          string `json:"id"`
+	Handle      string `json:"handle"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UserCount   int    `json:"userCount"`
+}
+
+type UserPresence struct {
+	UserID           string `json:"userID"`
+	UserName         string `json:"userName"`
+	RealName         string `json:"realName"`
+	Presence         string `json:"presence"`
+	DNDEnabled       bool   `json:"dndEnabled"`
+	DNDStart         string `json:"dndStart"`
+	DNDEnd           string `json:"dndEnd"`
+	SnoozeEnabled    bool   `json:"snoozeEnabled"`
+	SnoozeEnd        string `json:"snoozeEnd"`
+	StatusText       string `json:"statusText"`
+	StatusEmoji      string `json:"statusEmoji"`
+	StatusExpiration string `json:"statusExpiration"`
+}
+
+type setStatusParams struct {
+	text       string
+	emoji      string
+	expiration time.Time
+}
+
+type usersSearchParams struct {
+	query          string
+	title          string
+	timezone       string
+	includeBots    bool
+	includeDeleted bool
+	cursor         string
+	limit          int
+}
+
+type UsersHandler struct {
+	apiProvider *provider.ApiProvider
+	logger      *zap.Logger
+}
+
+func NewUsersHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *UsersHandler {
+	return &UsersHandler{
+		apiProvider: apiProvider,
+		logger:      logger,
+	}
+}
+
+// UsersSearchHandler searches the users cache and returns matching profiles as CSV
+func (uh *UsersHandler) UsersSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersSearchHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := uh.parseParamsToolUsersSearch(request)
+	if err != nil {
+		uh.logger.Error("Failed to parse users_search params", zap.Error(err))
+		return nil, err
+	}
+
+	users := filterUsers(uh.apiProvider.ProvideUsersMap().Users, params)
+	uh.logger.Debug("Users after filtering", zap.Int("count", len(users)))
+
+	paged, nextcur := paginateUsers(users, params.cursor, params.limit)
+
+	profiles := make([]UserProfile, 0, len(paged))
+	for _, u := range paged {
+		profiles = append(profiles, toUserProfile(u))
+	}
+	if len(profiles) > 0 && nextcur != "" {
+		profiles[len(profiles)-1].Cursor = nextcur
+	}
+
+	return marshalRows(request, profiles)
+}
+
+// UsersGetHandler returns the full profile of a single user as CSV
+func (uh *UsersHandler) UsersGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersGetHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	userParam := strings.TrimSpace(request.GetString("user", ""))
+	if userParam == "" {
+		return nil, errors.New("user is required")
+	}
+
+	user, err := uh.lookupUser(userParam)
+	if err != nil {
+		uh.logger.Error("Failed to lookup user", zap.String("user", userParam), zap.Error(err))
+		return nil, err
+	}
+
+	return marshalRows(request, []UserProfile{toUserProfile(*user)})
+}
+
+func (uh *UsersHandler) lookupUser(userParam string) (*slack.User, error) {
+	return lookupUser(uh.apiProvider, userParam)
+}
+
+// lookupUser resolves a user by ID, @username or email, falling back to
+// users.info for IDs that are missing from the cache
+func lookupUser(apiProvider *provider.ApiProvider, userParam string) (*slack.User, error) {
+	usersMaps := apiProvider.ProvideUsersMap()
+
+	if strings.HasPrefix(userParam, "@") {
+		id, ok := usersMaps.UsersInv[strings.TrimPrefix(userParam, "@")]
+		if !ok {
+			return nil, fmt.Errorf("user %q not found", userParam)
+		}
+		userParam = id
+	}
+
+	if u, ok := usersMaps.Users[userParam]; ok {
+		return &u, nil
+	}
+
+	if strings.Contains(userParam, "@") {
+		for _, u := range usersMaps.Users {
+			if strings.EqualFold(u.Profile.Email, userParam) {
+				return &u, nil
+			}
+		}
+		return nil, fmt.Errorf("user with email %q not found", userParam)
+	}
+
+	users, err := apiProvider.Slack().GetUsersInfo(userParam)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil || len(*users) == 0 {
+		return nil, fmt.Errorf("user %q not found", userParam)
+	}
+	return &(*users)[0], nil
+}
+
+func (uh *UsersHandler) parseParamsToolUsersSearch(request mcp.CallToolRequest) (*usersSearchParams, error) {
+	params := &usersSearchParams{
+		query:          strings.TrimSpace(request.GetString("query", "")),
+		title:          strings.TrimSpace(request.GetString("title", "")),
+		timezone:       strings.TrimSpace(request.GetString("timezone", "")),
+		includeBots:    request.GetBool("include_bots", false),
+		includeDeleted: request.GetBool("include_deleted", false),
+		cursor:         request.GetString("cursor", ""),
+		limit:          request.GetInt("limit", defaultUsersLimit),
+	}
+
+	if params.query == "" && params.title == "" && params.timezone == "" {
+		return nil, errors.New("at least one of query, title or timezone must be provided")
+	}
+	if params.limit <= 0 {
+		params.limit = defaultUsersLimit
+	}
+	if params.limit > maxUsersLimit {
+		uh.logger.Warn("Limit exceeds maximum, capping", zap.Int("requested", params.limit), zap.Int("max", maxUsersLimit))
+		params.limit = maxUsersLimit
+	}
+
+	return params, nil
+}
+
+// filterUsers returns users matching all of the given criteria. query is
+// matched case-insensitively against username, real name, display name and
+// email, title and timezone are matched as substrings of the respective fields.
+func filterUsers(users map[string]slack.User, params *usersSearchParams) []slack.User {
+	query := strings.ToLower(params.query)
+	title := strings.ToLower(params.title)
+	timezone := strings.ToLower(params.timezone)
+
+	var result []slack.User
+	for _, u := range users {
+		if u.IsBot && !params.includeBots {
+			continue
+		}
+		if u.Deleted && !params.includeDeleted {
+			continue
+		}
+		if query != "" {
+			fields := []string{u.Name, u.RealName, u.Profile.RealName, u.Profile.DisplayName, u.Profile.Email}
+			matched := false
+			for _, f := range fields {
+				if strings.Contains(strings.ToLower(f), query) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if title != "" && !strings.Contains(strings.ToLower(u.Profile.Title), title) {
+			continue
+		}
+		if timezone != "" && !strings.Contains(strings.ToLower(u.TZ), timezone) && !strings.Contains(strings.ToLower(u.TZLabel), timezone) {
+			continue
+		}
+		result = append(result, u)
+	}
+	return result
+}
+
+func paginateUsers(users []slack.User, cursor string, limit int) ([]slack.User, string) {
+	logger := zap.L()
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	startIndex := 0
+	if cursor != "" {
+		if decoded, err := base64.StdEncoding.DecodeString(cursor); err == nil {
+			lastID := string(decoded)
+			startIndex = len(users)
+			for i, u := range users {
+				if u.ID > lastID {
+					startIndex = i
+					break
+				}
+			}
+		} else {
+			logger.Warn("Failed to decode cursor",
+				zap.String("cursor", cursor),
+				zap.Error(err),
+			)
+		}
+	}
+
+	endIndex := startIndex + limit
+	if endIndex > len(users) {
+		endIndex = len(users)
+	}
+
+	var nextCursor string
+	if endIndex < len(users) {
+		nextCursor = base64.StdEncoding.EncodeToString([]byte(users[endIndex-1].ID))
+	}
+
+	return users[startIndex:endIndex], nextCursor
+}
+
+func toUserProfile(u slack.User) UserProfile {
+	realName := u.RealName
+	if realName == "" {
+		realName = u.Profile.RealName
+	}
+	return UserProfile{
+		UserID:       u.ID,
+		UserName:     u.Name,
+		RealName:     realName,
+		DisplayName:  u.Profile.DisplayName,
+		Email:        u.Profile.Email,
+		Title:        u.Profile.Title,
+		TZ:           u.TZ,
+		StatusText:   u.Profile.StatusText,
+		StatusEmoji:  u.Profile.StatusEmoji,
+		IsBot:        u.IsBot,
+		IsRestricted: u.IsRestricted || u.IsUltraRestricted,
+		Deleted:      u.Deleted,
+	}
+}
+
+// UsergroupsListHandler returns user groups (subteams) as CSV
+func (uh *UsersHandler) UsergroupsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsergroupsListHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	if err := uh.ensureUsergroups(ctx); err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(strings.TrimSpace(request.GetString("query", "")))
+	groups := uh.apiProvider.ProvideUsergroupsMap().Usergroups
+
+	list := make([]Usergroup, 0, len(groups))
+	for _, g := range groups {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(g.Handle), query) &&
+			!strings.Contains(strings.ToLower(g.Name), query) &&
+			!strings.Contains(strings.ToLower(g.Description), query) {
+			continue
+		}
+		userCount := g.UserCount
+		if userCount == 0 {
+			userCount = len(g.Users)
+		}
+		list = append(list, Usergroup{
+			ID:          g.ID,
+			Handle:      g.Handle,
+			Name:        g.Name,
+			Description: g.Description,
+			UserCount:   userCount,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Handle < list[j].Handle
+	})
+
+	return marshalRows(request, list)
+}
+
+// UsergroupsMembersHandler returns the profiles of user group members as CSV
+func (uh *UsersHandler) UsergroupsMembersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsergroupsMembersHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	usergroup := strings.TrimSpace(request.GetString("usergroup", ""))
+	if usergroup == "" {
+		return nil, errors.New("usergroup is required")
+	}
+
+	if err := uh.ensureUsergroups(ctx); err != nil {
+		return nil, err
+	}
+
+	groupsMap := uh.apiProvider.ProvideUsergroupsMap()
+	if strings.HasPrefix(usergroup, "@") {
+		id, ok := groupsMap.UsergroupsInv[strings.TrimPrefix(usergroup, "@")]
+		if !ok {
+			return nil, fmt.Errorf("user group %q not found", usergroup)
+		}
+		usergroup = id
+	}
+
+	var members []string
+	if g, ok := groupsMap.Usergroups[usergroup]; ok && len(g.Users) > 0 {
+		members = g.Users
+	} else {
+		var err error
+		members, err = uh.apiProvider.Slack().GetUserGroupMembersContext(ctx, usergroup)
+		if err != nil {
+			uh.logger.Error("Slack GetUserGroupMembersContext failed", zap.String("usergroup", usergroup), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	usersMap := uh.apiProvider.ProvideUsersMap().Users
+	profiles := make([]UserProfile, 0, len(members))
+	for _, id := range members {
+		u, ok := usersMap[id]
+		if !ok {
+			u = slack.User{ID: id}
+		}
+		profiles = append(profiles, toUserProfile(u))
+	}
+
+	return marshalRows(request, profiles)
+}
+
+// ensureUsergroups lazily populates the user groups cache if the initial
+// refresh did not succeed
+func (uh *UsersHandler) ensureUsergroups(ctx context.Context) error {
+	if uh.apiProvider.IsUsergroupsReady() {
+		return nil
+	}
+	if err := uh.apiProvider.RefreshUsergroups(ctx); err != nil {
+		return fmt.Errorf("failed to fetch user groups: %v", err)
+	}
+	return nil
+}
+
+// UsersPresenceHandler returns presence, DND window and custom status of one
+// or more users as CSV
+func (uh *UsersHandler) UsersPresenceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersPresenceHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	usersParam := strings.TrimSpace(request.GetString("users", ""))
+	if usersParam == "" {
+		return nil, errors.New("users is required")
+	}
+
+	var ids []string
+	for _, raw := range strings.Split(usersParam, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		user, err := uh.lookupUser(raw)
+		if err != nil {
+			uh.logger.Error("Failed to lookup user", zap.String("user", raw), zap.Error(err))
+			return nil, err
+		}
+		ids = append(ids, user.ID)
+	}
+	if len(ids) > maxPresenceUsers {
+		return nil, fmt.Errorf("at most %d users can be requested at once, got %d", maxPresenceUsers, len(ids))
+	}
+
+	// Custom status is short-lived, so profiles are fetched fresh instead
+	// of being taken from the users cache
+	users, err := uh.apiProvider.Slack().GetUsersInfo(ids...)
+	if err != nil {
+		uh.logger.Error("Slack GetUsersInfo failed", zap.Strings("users", ids), zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]UserPresence, 0, len(*users))
+	for _, u := range *users {
+		row := UserPresence{
+			UserID:           u.ID,
+			UserName:         u.Name,
+			RealName:         toUserProfile(u).RealName,
+			StatusText:       u.Profile.StatusText,
+			StatusEmoji:      u.Profile.StatusEmoji,
+			StatusExpiration: formatUnixTime(int64(u.Profile.StatusExpiration)),
+		}
+
+		presence, err := uh.apiProvider.Slack().GetUserPresenceContext(ctx, u.ID)
+		if err != nil {
+			uh.logger.Warn("Slack GetUserPresenceContext failed", zap.String("user", u.ID), zap.Error(err))
+		} else {
+			row.Presence = presence.Presence
+		}
+
+		userID := u.ID
+		dnd, err := uh.apiProvider.Slack().GetDNDInfoContext(ctx, &userID)
+		if err != nil {
+			uh.logger.Warn("Slack GetDNDInfoContext failed", zap.String("user", u.ID), zap.Error(err))
+		} else {
+			row.DNDEnabled = dnd.Enabled
+			row.DNDStart = formatUnixTime(int64(dnd.NextStartTimestamp))
+			row.DNDEnd = formatUnixTime(int64(dnd.NextEndTimestamp))
+			row.SnoozeEnabled = dnd.SnoozeEnabled
+			row.SnoozeEnd = formatUnixTime(int64(dnd.SnoozeEndTime))
+		}
+
+		result = append(result, row)
+	}
+
+	return marshalRows(request, result)
+}
+
+// UsersSetStatusHandler sets the custom status of the authenticated user and
+// returns the updated presence row as CSV
+func (uh *UsersHandler) UsersSetStatusHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uh.logger.Debug("UsersSetStatusHandler called", zap.Any("params", request.Params))
+
+	if ready, err := uh.apiProvider.IsReady(); !ready {
+		uh.logger.Error("API provider not ready", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := uh.parseParamsToolSetStatus(request)
+	if err != nil {
+		uh.logger.Error("Failed to parse users_set_status params", zap.Error(err))
+		return nil, err
+	}
+
+	var expiration int64
+	if !params.expiration.IsZero() {
+		expiration = params.expiration.Unix()
+	}
+
+	err = uh.apiProvider.Slack().SetUserCustomStatusContext(ctx, params.text, params.emoji, expiration)
+	if err != nil {
+		uh.logger.Error("Slack SetUserCustomStatusContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	if params.text == "" && params.emoji == "" {
+		return marshalStatus(request, "Successfully cleared custom status")
+	}
+	if expiration == 0 {
+		return marshalStatus(request, fmt.Sprintf("Successfully set custom status %s %q", params.emoji, params.text))
+	}
+	return marshalStatus(request, fmt.Sprintf("Successfully set custom status %s %q until %s",
+		params.emoji, params.text, params.expiration.Format(time.RFC3339)))
+}
+
+func (uh *UsersHandler) parseParamsToolSetStatus(request mcp.CallToolRequest) (*setStatusParams, error) {
+	toolConfig := os.Getenv("SLACK_MCP_SET_STATUS_TOOL")
+	if toolConfig == "" {
+		uh.logger.Error("Set status tool disabled by default")
+		return nil, errors.New(
+			"by default, the users_set_status tool is disabled. " +
+				"To enable it, set the SLACK_MCP_SET_STATUS_TOOL environment variable to true or 1",
+		)
+	}
+	if toolConfig != "true" && toolConfig != "1" {
+		uh.logger.Error("Set status tool disabled", zap.String("config", toolConfig))
+		return nil, errors.New("SLACK_MCP_SET_STATUS_TOOL must be set to 'true' or '1' to enable")
+	}
+
+	params := &setStatusParams{
+		text:  strings.TrimSpace(request.GetString("status_text", "")),
+		emoji: strings.Trim(strings.TrimSpace(request.GetString("status_emoji", "")), ":"),
+	}
+	if params.emoji != "" {
+		params.emoji = ":" + params.emoji + ":"
+	}
+	if len([]rune(params.text)) > maxStatusTextLength {
+		return nil, fmt.Errorf("status_text must be at most %d characters", maxStatusTextLength)
+	}
+
+	if exp := strings.TrimSpace(request.GetString("expiration", "")); exp != "" {
+		now := time.Now().In(authenticatedUserLocation(uh.apiProvider, uh.logger))
+		t, err := parseStatusExpiration(exp, now)
+		if err != nil {
+			return nil, err
+		}
+		params.expiration = t
+	}
+
+	return params, nil
+}
+
+// parseStatusExpiration accepts a duration relative to now (e.g. '30m',
+// '2h') or any absolute time supported by parsePostAt
+func parseStatusExpiration(value string, now time.Time) (time.Time, error) {
+	var t time.Time
+	if d, err := time.ParseDuration(value); err == nil {
+		t = now.Add(d)
+	} else {
+		t, err = parsePostAt(value, now)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid expiration %q: %v", value, err)
+		}
+	}
+	if !t.After(now) {
+		return time.Time{}, fmt.Errorf("expiration %q must be in the future", value)
+	}
+	return t, nil
+}
+
+func formatUnixTime(ts int64) string {
+	if ts <= 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestUnitFilterUsers(t *testing.T) {
+	users := map[string]slack.User{
+		"U1": {ID: "U1", Name: "john", RealName: "John Smith", TZ: "Europe/Berlin", Profile: slack.UserProfile{Email: "john@example.com", Title: "Backend Engineer"}},
+		"U2": {ID: "U2", Name: "jane", RealName: "Jane Doe", TZ: "America/New_York", Profile: slack.UserProfile{DisplayName: "JD", Title: "Product Manager"}},
+		"U3": {ID: "U3", Name: "deploybot", IsBot: true, TZ: "Europe/Berlin"},
+		"U4": {ID: "U4", Name: "johnny", Deleted: true, TZ: "Europe/Berlin"},
+	}
+
+	ids := func(us []slack.User) []string {
+		var out []string
+		for _, u := range us {
+			out = append(out, u.ID)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"U1"}, ids(filterUsers(users, &usersSearchParams{query: "JOHN"})))
+	assert.ElementsMatch(t, []string{"U1", "U4"}, ids(filterUsers(users, &usersSearchParams{query: "john", includeDeleted: true})))
+	assert.ElementsMatch(t, []string{"U2"}, ids(filterUsers(users, &usersSearchParams{query: "jd"})))
+	assert.ElementsMatch(t, []string{"U1"}, ids(filterUsers(users, &usersSearchParams{query: "example.com"})))
+	assert.ElementsMatch(t, []string{"U2"}, ids(filterUsers(users, &usersSearchParams{title: "manager"})))
+	assert.ElementsMatch(t, []string{"U1", "U3"}, ids(filterUsers(users, &usersSearchParams{timezone: "berlin", includeBots: true})))
+}
+
+func TestUnitPaginateUsers(t *testing.T) {
+	users := []slack.User{{ID: "U3"}, {ID: "U1"}, {ID: "U2"}}
+
+	page, cursor := paginateUsers(users, "", 2)
+	assert.Equal(t, "U1", page[0].ID)
+	assert.Equal(t, "U2", page[1].ID)
+	assert.NotEmpty(t, cursor)
+
+	page, cursor = paginateUsers(users, cursor, 2)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "U3", page[0].ID)
+	assert.Empty(t, cursor)
+}
+
+func TestUnitParseParamsToolSetStatusConfig(t *testing.T) {
+	uh := &UsersHandler{logger: zap.NewNop()}
+	for _, config := range []string{"", "yes", "C123"} {
+		t.Setenv("SLACK_MCP_SET_STATUS_TOOL", config)
+		_, err := uh.parseParamsToolSetStatus(mcp.CallToolRequest{})
+		assert.Errorf(t, err, "expected error for %q", config)
+	}
+
+	t.Setenv("SLACK_MCP_SET_STATUS_TOOL", "1")
+	var req mcp.CallToolRequest
+	req.Params.Arguments = map[string]any{"status_text": "Focus time", "status_emoji": "headphones"}
+	params, err := uh.parseParamsToolSetStatus(req)
+	assert.NoError(t, err)
+	assert.Equal(t, ":headphones:", params.emoji)
+}
+
+func TestUnitParseStatusExpiration(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2025, 7, 16, 12, 0, 0, 0, loc)
+
+	got, err := parseStatusExpiration("90m", now)
+	assert.NoError(t, err)
+	assert.True(t, got.Equal(now.Add(90*time.Minute)))
+
+	got, err = parseStatusExpiration("tomorrow 9am", now)
+	assert.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2025, 7, 17, 9, 0, 0, 0, loc)))
+
+	for _, input := range []string{"-1h", "1752000000", "not a time"} {
+		_, err := parseStatusExpiration(input, now)
+		assert.Errorf(t, err, "expected error for %q", input)
+	}
+}
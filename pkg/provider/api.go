@@ -6,13 +6,18 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
+	_ "unsafe" // for go:linkname
 
 	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
 	"github.com/korotovsky/slack-mcp-server/pkg/transport"
+	edgeslack "github.com/rusq/slack"
 	"github.com/rusq/slackdump/v3/auth"
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
@@ -27,6 +32,10 @@ var AllChanTypes = []string{"mpim", "im", "public_channel", "private_channel"}
 var PrivateChanType = "private_channel"
 var PubChanType = "public_channel"
 
+// ArchivedChanType selects archived public and private channels, they are
+// cached apart from the active ones.
+var ArchivedChanType = "archived"
+
 var ErrUsersNotReady = errors.New(usersNotReadyMsg)
 var ErrChannelsNotReady = errors.New(channelsNotReadyMsg)
 
@@ -51,6 +60,18 @@ type UsersCache struct {
 	UsersInv map[string]string     `json:"users_inv"`
 }
 
+type EmojiCache struct {
+	// Custom maps custom emoji names to their image URL or "alias:<name>"
+	Custom map[string]string `json:"custom"`
+	// Standard holds names of the standard (unicode) emoji
+	Standard map[string]struct{} `json:"standard"`
+}
+
+type UsergroupsCache struct {
+	Usergroups    map[string]slack.UserGroup `json:"usergroups"`
+	UsergroupsInv map[string]string          `json:"usergroups_inv"`
+}
+
 type ChannelsCache struct {
 	Channels    map[string]Channel `json:"channels"`
 	ChannelsInv map[string]string  `json:"channels_inv"`
@@ -67,23 +88,49 @@ type Channel struct {
 	IsPrivate   bool     `json:"private"`
 	User        string   `json:"user,omitempty"`    // User ID for IM channels
 	Members     []string `json:"members,omitempty"` // Member IDs for the channel
+	IsArchived  bool     `json:"archived,omitempty"`
 }
 
 type SlackAPI interface {
 	// Standard slack-go API methods
 	AuthTest() (*slack.AuthTestResponse, error)
 	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
+	GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error)
 	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
 	GetUsersInfo(users ...string) (*[]slack.User, error)
+	GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error)
+	GetDNDInfoContext(ctx context.Context, user *string) (*slack.DNDStatus, error)
+	SetUserCustomStatusContext(ctx context.Context, statusText, statusEmoji string, statusExpiration int64) error
+	GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+	GetUserGroupMembersContext(ctx context.Context, userGroup string, options ...slack.GetUserGroupMembersOption) ([]string, error)
 	PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessageContext(ctx context.Context, channel, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+	DeleteMessageContext(ctx context.Context, channel, timestamp string) (string, string, error)
+	UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
+	ScheduleMessageContext(ctx context.Context, channel, postAt string, options ...slack.MsgOption) (string, string, error)
+	GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)
+	DeleteScheduledMessageContext(ctx context.Context, params *slack.DeleteScheduledMessageParameters) (bool, error)
 	MarkConversationContext(ctx context.Context, channel, ts string) error
 	AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error
 	RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error)
+	ListReactionsContext(ctx context.Context, params slack.ListReactionsParameters) ([]slack.ReactedItem, *slack.Paging, error)
+	ListPinsContext(ctx context.Context, channel string) ([]slack.Item, *slack.Paging, error)
+	AddPinContext(ctx context.Context, channel string, item slack.ItemRef) error
+	RemovePinContext(ctx context.Context, channel string, item slack.ItemRef) error
+	ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error)
+	AddBookmarkContext(ctx context.Context, channelID string, params slack.AddBookmarkParameters) (slack.Bookmark, error)
+	RemoveBookmarkContext(ctx context.Context, channelID, bookmarkID string) error
+	ListStarsContext(ctx context.Context, params slack.StarsParameters) ([]slack.Item, *slack.Paging, error)
+	AddStarContext(ctx context.Context, channel string, item slack.ItemRef) error
+	RemoveStarContext(ctx context.Context, channel string, item slack.ItemRef) error
 
 	// Used to get messages
 	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
 	GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error)
 	SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error)
+	SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+	GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error)
 
 	// Used to get files
 	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
@@ -92,8 +139,28 @@ type SlackAPI interface {
 	// Used to get channels list from both Slack and Enterprise Grid versions
 	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
 
+	// Used to get channel details and members
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+
+	// Used to manage channels
+	CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error)
+	ArchiveConversationContext(ctx context.Context, channelID string) error
+	UnArchiveConversationContext(ctx context.Context, channelID string) error
+	InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error)
+	KickUserFromConversationContext(ctx context.Context, channelID string, user string) error
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+	OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error)
+
 	// Edge API methods
 	ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error)
+	ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error)
+	SubscriptionsThreadMark(ctx context.Context, channel, threadTs, ts string) error
+	EmojiList(ctx context.Context) (*edge.EmojiListResponse, error)
+	SavedList(ctx context.Context, filter, cursor string, limit int) (*edge.SavedListResponse, error)
+	SavedAdd(ctx context.Context, channel, ts string) error
+	SavedDelete(ctx context.Context, channel, ts string) error
 }
 
 type MCPSlackClient struct {
@@ -121,10 +188,36 @@ type ApiProvider struct {
 	usersCache string
 	usersReady bool
 
+	// mu guards the channels, user groups and emoji caches and their state.
+	// Tool calls and background refreshes modify them concurrently,
+	// so the maps are copied, modified and swapped under mu and maps handed
+	// out by ProvideChannelsMaps are never written again
+	mu sync.RWMutex
+
 	channels      map[string]Channel
 	channelsInv   map[string]string
 	channelsCache string
 	channelsReady bool
+
+	archivedChannels      map[string]Channel
+	archivedChannelsInv   map[string]string
+	archivedChannelsCache string
+	archivedChannelsReady bool
+	// archivedChanged records the channels archived or unarchived by tools
+	// while RefreshArchivedChannels runs, it is nil otherwise
+	archivedChanged map[string]struct{}
+
+	// cacheMu orders writes of the channels cache files, which happen
+	// outside of mu so that readers do not wait for disk I/O
+	cacheMu sync.Mutex
+
+	usergroups      map[string]slack.UserGroup
+	usergroupsInv   map[string]string
+	usergroupsReady bool
+
+	emojiCustom    map[string]string
+	emojiStandard  map[string]struct{}
+	emojiRefreshed time.Time
 }
 
 func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlackClient, error) {
@@ -208,6 +301,10 @@ func (c *MCPSlackClient) AuthTestContext(ctx context.Context) (*slack.AuthTestRe
 	return c.slackClient.AuthTestContext(ctx)
 }
 
+func (c *MCPSlackClient) GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error) {
+	return c.slackClient.GetPermalinkContext(ctx, params)
+}
+
 func (c *MCPSlackClient) GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error) {
 	return c.slackClient.GetUsersContext(ctx, options...)
 }
@@ -216,6 +313,92 @@ func (c *MCPSlackClient) GetUsersInfo(users ...string) (*[]slack.User, error) {
 	return c.slackClient.GetUsersInfo(users...)
 }
 
+func (c *MCPSlackClient) GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error) {
+	return c.slackClient.GetUserPresenceContext(ctx, user)
+}
+
+func (c *MCPSlackClient) GetDNDInfoContext(ctx context.Context, user *string) (*slack.DNDStatus, error) {
+	status, err := c.slackClient.GetDNDInfoContext(ctx, user)
+	if err == nil || c.isOAuth {
+		return status, err
+	}
+
+	// Browser sessions carry DND of the current user in the boot data,
+	// use it if dnd.info is not available.
+	if user != nil && *user != c.authResponse.UserID {
+		return nil, err
+	}
+	boot, bootErr := c.edgeClient.ClientUserBoot(ctx)
+	if bootErr != nil {
+		return nil, err
+	}
+
+	return &slack.DNDStatus{
+		Enabled:            boot.DND.DNDEnabled,
+		NextStartTimestamp: int(boot.DND.NextDNDStartTs),
+		NextEndTimestamp:   int(boot.DND.NextDNDEndTs),
+		SnoozeInfo: slack.SnoozeInfo{
+			SnoozeEnabled: boot.DND.SnoozeEnabled,
+		},
+	}, nil
+}
+
+func (c *MCPSlackClient) SetUserCustomStatusContext(ctx context.Context, statusText, statusEmoji string, statusExpiration int64) error {
+	return c.slackClient.SetUserCustomStatusContext(ctx, statusText, statusEmoji, statusExpiration)
+}
+
+func (c *MCPSlackClient) GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	groups, err := c.slackClient.GetUserGroupsContext(ctx, options...)
+	if err == nil || c.isOAuth {
+		return groups, err
+	}
+
+	// Browser sessions may be denied usergroups.list on some workspaces,
+	// fallback to subteams the user belongs to from the boot data.
+	boot, bootErr := c.edgeClient.ClientUserBoot(ctx)
+	if bootErr != nil {
+		return nil, err
+	}
+
+	return subteamsToUserGroups(boot.Subteams), nil
+}
+
+// subteamsToUserGroups converts subteams of the current user from the boot
+// data. Depending on the client version they are listed either as bare
+// subteam IDs or as subteam objects.
+func subteamsToUserGroups(subteams edge.Subteams) []slack.UserGroup {
+	var groups []slack.UserGroup
+	for _, v := range subteams.Self {
+		switch st := v.(type) {
+		case string:
+			groups = append(groups, slack.UserGroup{ID: st, IsUserGroup: true})
+		case map[string]any:
+			g := slack.UserGroup{IsUserGroup: true}
+			g.ID, _ = st["id"].(string)
+			g.TeamID, _ = st["team_id"].(string)
+			g.Name, _ = st["name"].(string)
+			g.Handle, _ = st["handle"].(string)
+			g.Description, _ = st["description"].(string)
+			if users, ok := st["users"].([]any); ok {
+				for _, u := range users {
+					if id, ok := u.(string); ok {
+						g.Users = append(g.Users, id)
+					}
+				}
+				g.UserCount = len(g.Users)
+			}
+			if g.ID != "" {
+				groups = append(groups, g)
+			}
+		}
+	}
+	return groups
+}
+
+func (c *MCPSlackClient) GetUserGroupMembersContext(ctx context.Context, userGroup string, options ...slack.GetUserGroupMembersOption) ([]string, error) {
+	return c.slackClient.GetUserGroupMembersContext(ctx, userGroup, options...)
+}
+
 func (c *MCPSlackClient) MarkConversationContext(ctx context.Context, channel, ts string) error {
 	return c.slackClient.MarkConversationContext(ctx, channel, ts)
 }
@@ -277,6 +460,108 @@ func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *sl
 	return c.slackClient.GetConversationsContext(ctx, params)
 }
 
+func (c *MCPSlackClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	if c.isOAuth {
+		return c.slackClient.GetConversationInfoContext(ctx, input)
+	}
+
+	ec, err := c.edgeClient.GetConversationInfoContext(ctx, &edgeslack.GetConversationInfoInput{
+		ChannelID:         input.ChannelID,
+		IncludeLocale:     input.IncludeLocale,
+		IncludeNumMembers: input.IncludeNumMembers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	channel := edgeChannelToSlack(ec)
+	return &channel, nil
+}
+
+func (c *MCPSlackClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	if c.isOAuth {
+		return c.slackClient.GetUsersInConversationContext(ctx, params)
+	}
+
+	// Edge client returns all members at once, so there is no cursor.
+	return c.edgeClient.GetUsersInConversationContext(ctx, &edgeslack.GetUsersInConversationParameters{
+		ChannelID: params.ChannelID,
+		Cursor:    params.Cursor,
+		Limit:     params.Limit,
+	})
+}
+
+func (c *MCPSlackClient) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	return c.slackClient.OpenConversationContext(ctx, params)
+}
+
+func (c *MCPSlackClient) CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+	return c.slackClient.CreateConversationContext(ctx, params)
+}
+
+func (c *MCPSlackClient) ArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.slackClient.ArchiveConversationContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.slackClient.UnArchiveConversationContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error) {
+	return c.slackClient.InviteUsersToConversationContext(ctx, channelID, users...)
+}
+
+func (c *MCPSlackClient) KickUserFromConversationContext(ctx context.Context, channelID string, user string) error {
+	return c.slackClient.KickUserFromConversationContext(ctx, channelID, user)
+}
+
+func (c *MCPSlackClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	return c.slackClient.SetTopicOfConversationContext(ctx, channelID, topic)
+}
+
+func (c *MCPSlackClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	return c.slackClient.SetPurposeOfConversationContext(ctx, channelID, purpose)
+}
+
+// edgeChannelToSlack converts a channel returned by the edge client into its
+// slack-go counterpart, keeping the metadata exposed by conversations.info.
+func edgeChannelToSlack(ec *edgeslack.Channel) slack.Channel {
+	return slack.Channel{
+		IsGeneral: ec.IsGeneral,
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID:                 ec.ID,
+				Created:            slack.JSONTime(ec.Created),
+				IsIM:               ec.IsIM,
+				IsMpIM:             ec.IsMpIM,
+				IsPrivate:          ec.IsPrivate,
+				IsShared:           ec.IsShared,
+				IsExtShared:        ec.IsExtShared,
+				IsOrgShared:        ec.IsOrgShared,
+				IsPendingExtShared: ec.IsPendingExtShared,
+				NameNormalized:     ec.NameNormalized,
+				NumMembers:         ec.NumMembers,
+				User:               ec.User,
+				PreviousNames:      ec.PreviousNames,
+			},
+			Name:       ec.Name,
+			Creator:    ec.Creator,
+			IsArchived: ec.IsArchived,
+			Members:    ec.Members,
+			Topic: slack.Topic{
+				Value:   ec.Topic.Value,
+				Creator: ec.Topic.Creator,
+				LastSet: slack.JSONTime(ec.Topic.LastSet),
+			},
+			Purpose: slack.Purpose{
+				Value:   ec.Purpose.Value,
+				Creator: ec.Purpose.Creator,
+				LastSet: slack.JSONTime(ec.Purpose.LastSet),
+			},
+		},
+	}
+}
+
 func (c *MCPSlackClient) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
 	return c.slackClient.GetConversationHistoryContext(ctx, params)
 }
@@ -289,10 +574,42 @@ func (c *MCPSlackClient) SearchContext(ctx context.Context, query string, params
 	return c.slackClient.SearchContext(ctx, query, params)
 }
 
+func (c *MCPSlackClient) SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	return c.slackClient.SearchFilesContext(ctx, query, params)
+}
+
+func (c *MCPSlackClient) GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error) {
+	return c.slackClient.GetFilesContext(ctx, params)
+}
+
 func (c *MCPSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
 	return c.slackClient.PostMessageContext(ctx, channelID, options...)
 }
 
+func (c *MCPSlackClient) UpdateMessageContext(ctx context.Context, channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	return c.slackClient.UpdateMessageContext(ctx, channelID, timestamp, options...)
+}
+
+func (c *MCPSlackClient) DeleteMessageContext(ctx context.Context, channelID, timestamp string) (string, string, error) {
+	return c.slackClient.DeleteMessageContext(ctx, channelID, timestamp)
+}
+
+func (c *MCPSlackClient) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	return c.slackClient.UploadFileV2Context(ctx, params)
+}
+
+func (c *MCPSlackClient) ScheduleMessageContext(ctx context.Context, channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+	return c.slackClient.ScheduleMessageContext(ctx, channelID, postAt, options...)
+}
+
+func (c *MCPSlackClient) GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+	return c.slackClient.GetScheduledMessagesContext(ctx, params)
+}
+
+func (c *MCPSlackClient) DeleteScheduledMessageContext(ctx context.Context, params *slack.DeleteScheduledMessageParameters) (bool, error) {
+	return c.slackClient.DeleteScheduledMessageContext(ctx, params)
+}
+
 func (c *MCPSlackClient) AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
 	return c.slackClient.AddReactionContext(ctx, name, item)
 }
@@ -301,6 +618,50 @@ func (c *MCPSlackClient) RemoveReactionContext(ctx context.Context, name string,
 	return c.slackClient.RemoveReactionContext(ctx, name, item)
 }
 
+func (c *MCPSlackClient) ListPinsContext(ctx context.Context, channel string) ([]slack.Item, *slack.Paging, error) {
+	return c.slackClient.ListPinsContext(ctx, channel)
+}
+
+func (c *MCPSlackClient) AddPinContext(ctx context.Context, channel string, item slack.ItemRef) error {
+	return c.slackClient.AddPinContext(ctx, channel, item)
+}
+
+func (c *MCPSlackClient) RemovePinContext(ctx context.Context, channel string, item slack.ItemRef) error {
+	return c.slackClient.RemovePinContext(ctx, channel, item)
+}
+
+func (c *MCPSlackClient) ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error) {
+	return c.slackClient.ListBookmarksContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) AddBookmarkContext(ctx context.Context, channelID string, params slack.AddBookmarkParameters) (slack.Bookmark, error) {
+	return c.slackClient.AddBookmarkContext(ctx, channelID, params)
+}
+
+func (c *MCPSlackClient) RemoveBookmarkContext(ctx context.Context, channelID, bookmarkID string) error {
+	return c.slackClient.RemoveBookmarkContext(ctx, channelID, bookmarkID)
+}
+
+func (c *MCPSlackClient) GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	return c.slackClient.GetReactionsContext(ctx, item, params)
+}
+
+func (c *MCPSlackClient) ListReactionsContext(ctx context.Context, params slack.ListReactionsParameters) ([]slack.ReactedItem, *slack.Paging, error) {
+	return c.slackClient.ListReactionsContext(ctx, params)
+}
+
+func (c *MCPSlackClient) ListStarsContext(ctx context.Context, params slack.StarsParameters) ([]slack.Item, *slack.Paging, error) {
+	return c.slackClient.ListStarsContext(ctx, params)
+}
+
+func (c *MCPSlackClient) AddStarContext(ctx context.Context, channel string, item slack.ItemRef) error {
+	return c.slackClient.AddStarContext(ctx, channel, item)
+}
+
+func (c *MCPSlackClient) RemoveStarContext(ctx context.Context, channel string, item slack.ItemRef) error {
+	return c.slackClient.RemoveStarContext(ctx, channel, item)
+}
+
 func (c *MCPSlackClient) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
 	return c.slackClient.GetFileInfoContext(ctx, fileID, count, page)
 }
@@ -313,6 +674,32 @@ func (c *MCPSlackClient) ClientUserBoot(ctx context.Context) (*edge.ClientUserBo
 	return c.edgeClient.ClientUserBoot(ctx)
 }
 
+func (c *MCPSlackClient) ClientCounts(ctx context.Context) (edge.ClientCountsResponse, error) {
+	return c.edgeClient.ClientCounts(ctx)
+}
+
+func (c *MCPSlackClient) SubscriptionsThreadMark(ctx context.Context, channel, threadTs, ts string) error {
+	return c.edgeClient.SubscriptionsThreadMark(ctx, channel, threadTs, ts)
+}
+
+// EmojiList goes through the edge client for all token types, as emoji.list
+// is a public method, but slack-go does not support include_categories.
+func (c *MCPSlackClient) EmojiList(ctx context.Context) (*edge.EmojiListResponse, error) {
+	return c.edgeClient.EmojiList(ctx)
+}
+
+func (c *MCPSlackClient) SavedList(ctx context.Context, filter, cursor string, limit int) (*edge.SavedListResponse, error) {
+	return c.edgeClient.SavedList(ctx, filter, cursor, limit)
+}
+
+func (c *MCPSlackClient) SavedAdd(ctx context.Context, channel, ts string) error {
+	return c.edgeClient.SavedAdd(ctx, channel, ts)
+}
+
+func (c *MCPSlackClient) SavedDelete(ctx context.Context, channel, ts string) error {
+	return c.edgeClient.SavedDelete(ctx, channel, ts)
+}
+
 func (c *MCPSlackClient) IsEnterprise() bool {
 	return c.isEnterprise
 }
@@ -325,6 +712,10 @@ func (c *MCPSlackClient) IsBotToken() bool {
 	return c.isBotToken
 }
 
+func (c *MCPSlackClient) IsOAuth() bool {
+	return c.isOAuth
+}
+
 func (c *MCPSlackClient) Raw() struct {
 	Slack *slack.Client
 	Edge  *edge.Client
@@ -416,6 +807,12 @@ func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		channelsCache = filepath.Join(cacheDir, "channels_cache_v2.json")
 	}
 
+	archivedChannelsCache := os.Getenv("SLACK_MCP_ARCHIVED_CHANNELS_CACHE")
+	if archivedChannelsCache == "" {
+		cacheDir := getCacheDir()
+		archivedChannelsCache = filepath.Join(cacheDir, "archived_channels_cache.json")
+	}
+
 	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
 		logger.Info("Demo credentials are set, skip.")
 	} else {
@@ -439,7 +836,52 @@ func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		channels:      make(map[string]Channel),
 		channelsInv:   map[string]string{},
 		channelsCache: channelsCache,
+
+		archivedChannels:      make(map[string]Channel),
+		archivedChannelsInv:   map[string]string{},
+		archivedChannelsCache: archivedChannelsCache,
+
+		usergroups:    make(map[string]slack.UserGroup),
+		usergroupsInv: map[string]string{},
+	}
+}
+
+// newWithClient returns a provider backed by client with users and channels
+// cached and reported ready. It does not persist caches and is only used by
+// handler tests, which link to it to run against a fake SlackAPI
+//
+//go:linkname newWithClient
+func newWithClient(client SlackAPI, users []slack.User, channels []Channel, logger *zap.Logger) *ApiProvider {
+	ap := &ApiProvider{
+		client: client,
+		logger: logger,
+
+		rateLimiter: limiter.Tier2.Limiter(),
+
+		users:      make(map[string]slack.User, len(users)),
+		usersInv:   make(map[string]string, len(users)),
+		usersReady: true,
+
+		channels:      make(map[string]Channel, len(channels)),
+		channelsInv:   make(map[string]string, len(channels)),
+		channelsReady: true,
+
+		archivedChannels:      make(map[string]Channel),
+		archivedChannelsInv:   map[string]string{},
+		archivedChannelsReady: true,
+
+		usergroups:    make(map[string]slack.UserGroup),
+		usergroupsInv: map[string]string{},
+	}
+	for _, u := range users {
+		ap.users[u.ID] = u
+		ap.usersInv[u.Name] = u.ID
+	}
+	for _, c := range channels {
+		ap.channels[c.ID] = c
+		ap.channelsInv[c.Name] = c.ID
 	}
+	return ap
 }
 
 func newWithXOXB(transport string, authProvider auth.ValueAuth, logger *zap.Logger) *ApiProvider {
@@ -466,6 +908,12 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		channelsCache = filepath.Join(cacheDir, "channels_cache_v2.json")
 	}
 
+	archivedChannelsCache := os.Getenv("SLACK_MCP_ARCHIVED_CHANNELS_CACHE")
+	if archivedChannelsCache == "" {
+		cacheDir := getCacheDir()
+		archivedChannelsCache = filepath.Join(cacheDir, "archived_channels_cache.json")
+	}
+
 	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
 		logger.Info("Demo credentials are set, skip.")
 	} else {
@@ -489,6 +937,13 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		channels:      make(map[string]Channel),
 		channelsInv:   map[string]string{},
 		channelsCache: channelsCache,
+
+		archivedChannels:      make(map[string]Channel),
+		archivedChannelsInv:   map[string]string{},
+		archivedChannelsCache: archivedChannelsCache,
+
+		usergroups:    make(map[string]slack.UserGroup),
+		usergroupsInv: map[string]string{},
 	}
 }
 
@@ -577,6 +1032,8 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 		} else {
 			// Re-map channels with current users cache to ensure DM names are populated
 			usersMap := ap.ProvideUsersMap().Users
+			channels := make(map[string]Channel, len(cachedChannels))
+			channelsInv := make(map[string]string, len(cachedChannels))
 			for _, c := range cachedChannels {
 				// For IM channels, re-generate the name and purpose using current users cache
 				if c.IsIM {
@@ -587,23 +1044,327 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 						c.IsIM, c.IsMpIM, c.IsPrivate,
 						usersMap,
 					)
-					ap.channels[c.ID] = remappedChannel
-					ap.channelsInv[remappedChannel.Name] = c.ID
+					channels[c.ID] = remappedChannel
+					channelsInv[remappedChannel.Name] = c.ID
 				} else {
-					ap.channels[c.ID] = c
-					ap.channelsInv[c.Name] = c.ID
+					channels[c.ID] = c
+					channelsInv[c.Name] = c.ID
 				}
 			}
 			ap.logger.Info("Loaded channels from cache and re-mapped DM names",
 				zap.Int("count", len(cachedChannels)),
 				zap.String("cache_file", ap.channelsCache))
+
+			ap.mu.Lock()
+			ap.channels = channels
+			ap.channelsInv = channelsInv
 			ap.channelsReady = true
+			ap.mu.Unlock()
 			return nil
 		}
 	}
 
-	channels := ap.GetChannels(ctx, AllChanTypes)
+	ap.GetChannels(ctx, AllChanTypes)
+	ap.persistChannelsCaches(true, false)
+
+	ap.mu.Lock()
+	ap.channelsReady = true
+	ap.mu.Unlock()
 
+	return nil
+}
+
+// RefreshArchivedChannels caches archived public and private channels apart
+// from the active ones, as listing them requires another full pass over
+// conversations.list and most tools never need them.
+func (ap *ApiProvider) RefreshArchivedChannels(ctx context.Context) error {
+	ap.mu.Lock()
+	ap.archivedChanged = make(map[string]struct{})
+	ap.mu.Unlock()
+	defer func() {
+		ap.mu.Lock()
+		ap.archivedChanged = nil
+		ap.mu.Unlock()
+	}()
+
+	if data, err := ioutil.ReadFile(ap.archivedChannelsCache); err == nil {
+		var cachedChannels []Channel
+		if err := json.Unmarshal(data, &cachedChannels); err != nil {
+			ap.logger.Warn("Failed to unmarshal archived channels cache, will refetch",
+				zap.String("cache_file", ap.archivedChannelsCache),
+				zap.Error(err))
+		} else {
+			archived := make(map[string]Channel, len(cachedChannels))
+			for _, c := range cachedChannels {
+				archived[c.ID] = c
+			}
+			ap.logger.Info("Loaded archived channels from cache",
+				zap.Int("count", len(cachedChannels)),
+				zap.String("cache_file", ap.archivedChannelsCache))
+			ap.publishArchivedChannels(archived, false)
+			return nil
+		}
+	}
+
+	archived := make(map[string]Channel)
+	for _, t := range []string{PubChanType, PrivateChanType} {
+		channels, err := ap.getChannelsType(ctx, t, false)
+		if err != nil {
+			return err
+		}
+		for _, c := range channels {
+			// The edge client lists all conversation types at once
+			if !c.IsArchived || c.IsIM || c.IsMpIM {
+				continue
+			}
+			archived[c.ID] = c
+		}
+	}
+	ap.publishArchivedChannels(archived, true)
+
+	return nil
+}
+
+// publishArchivedChannels swaps in the fetched archived channels, keeping the
+// state of channels archived or unarchived by tools since the refresh started
+func (ap *ApiProvider) publishArchivedChannels(archived map[string]Channel, persist bool) {
+	ap.mu.Lock()
+	for id := range ap.archivedChanged {
+		if c, ok := ap.archivedChannels[id]; ok {
+			archived[id] = c
+		} else {
+			delete(archived, id)
+		}
+	}
+	archivedInv := make(map[string]string, len(archived))
+	for _, c := range archived {
+		archivedInv[c.Name] = c.ID
+	}
+
+	ap.archivedChannels = archived
+	ap.archivedChannelsInv = archivedInv
+	ap.archivedChannelsReady = true
+	ap.mu.Unlock()
+
+	if persist {
+		ap.persistChannelsCaches(false, true)
+	}
+}
+
+func (ap *ApiProvider) writeArchivedChannelsCache(archivedChannels map[string]Channel) {
+	if ap.archivedChannelsCache == "" {
+		return
+	}
+	data, err := json.MarshalIndent(channelsSlice(archivedChannels), "", "  ")
+	if err != nil {
+		ap.logger.Error("Failed to marshal archived channels for cache", zap.Error(err))
+		return
+	}
+	if err := ioutil.WriteFile(ap.archivedChannelsCache, data, 0644); err != nil {
+		ap.logger.Error("Failed to write archived channels cache file",
+			zap.String("cache_file", ap.archivedChannelsCache),
+			zap.Error(err))
+		return
+	}
+	ap.logger.Debug("Wrote archived channels to cache",
+		zap.Int("count", len(archivedChannels)),
+		zap.String("cache_file", ap.archivedChannelsCache))
+}
+
+// UpsertChannel adds a created or modified channel to the channels cache and
+// persists the cache, so that it can be resolved by its #name right away.
+func (ap *ApiProvider) UpsertChannel(channel slack.Channel) Channel {
+	ch := mapChannel(
+		channel.ID,
+		channel.Name,
+		channel.NameNormalized,
+		channel.Topic.Value,
+		channel.Purpose.Value,
+		channel.User,
+		channel.Members,
+		channel.NumMembers,
+		channel.IsIM,
+		channel.IsMpIM,
+		channel.IsPrivate,
+		ap.ProvideUsersMap().Users,
+	)
+	if channel.NameNormalized == "" && !channel.IsIM {
+		if channel.IsMpIM {
+			ch.Name = "@" + channel.Name
+		} else {
+			ch.Name = "#" + channel.Name
+		}
+	}
+
+	ap.mu.Lock()
+	caches := ap.cloneChannelsLocked()
+	defer ap.publishChannelsAndUnlock(caches)
+
+	if channel.IsArchived {
+		ch.IsArchived = true
+		return caches.upsertArchived(ch)
+	}
+	if prev, ok := caches.archivedChannels[ch.ID]; ok {
+		caches.removeArchived(prev)
+	}
+
+	// Keep what we already know if the API response is partial
+	if prev, ok := caches.channels[ch.ID]; ok {
+		if ch.MemberCount == 0 {
+			ch.MemberCount = prev.MemberCount
+		}
+		if len(ch.Members) == 0 {
+			ch.Members = prev.Members
+		}
+		if prev.Name != ch.Name {
+			delete(caches.channelsInv, prev.Name)
+		}
+	}
+
+	caches.channels[ch.ID] = ch
+	caches.channelsInv[ch.Name] = ch.ID
+	caches.channelsChanged = true
+
+	return ch
+}
+
+// SetChannelArchived moves a channel between the active and the archived
+// channels caches after it was archived or unarchived.
+func (ap *ApiProvider) SetChannelArchived(channelID string, archived bool) {
+	ap.mu.Lock()
+	caches := ap.cloneChannelsLocked()
+	defer ap.publishChannelsAndUnlock(caches)
+
+	if archived {
+		ch, ok := caches.channels[channelID]
+		if !ok {
+			return
+		}
+		ch.IsArchived = true
+		caches.upsertArchived(ch)
+		return
+	}
+
+	ch, ok := caches.archivedChannels[channelID]
+	if !ok {
+		return
+	}
+	caches.removeArchived(ch)
+
+	ch.IsArchived = false
+	caches.channels[ch.ID] = ch
+	caches.channelsInv[ch.Name] = ch.ID
+	caches.channelsChanged = true
+}
+
+// channelsCaches holds copies of the active and archived channels caches
+// which are modified and then swapped in by publishChannelsAndUnlock
+type channelsCaches struct {
+	channels            map[string]Channel
+	channelsInv         map[string]string
+	archivedChannels    map[string]Channel
+	archivedChannelsInv map[string]string
+
+	channelsChanged bool
+	archivedChanged bool
+	archivedIDs     []string
+}
+
+// cloneChannelsLocked copies the channels caches, ap.mu must be held
+func (ap *ApiProvider) cloneChannelsLocked() *channelsCaches {
+	return &channelsCaches{
+		channels:            maps.Clone(ap.channels),
+		channelsInv:         maps.Clone(ap.channelsInv),
+		archivedChannels:    maps.Clone(ap.archivedChannels),
+		archivedChannelsInv: maps.Clone(ap.archivedChannelsInv),
+	}
+}
+
+// publishChannelsAndUnlock swaps in the modified caches, releases ap.mu,
+// which must be held, and persists them
+func (ap *ApiProvider) publishChannelsAndUnlock(caches *channelsCaches) {
+	if caches.channelsChanged {
+		ap.channels = caches.channels
+		ap.channelsInv = caches.channelsInv
+	}
+	if caches.archivedChanged {
+		ap.archivedChannels = caches.archivedChannels
+		ap.archivedChannelsInv = caches.archivedChannelsInv
+		if ap.archivedChanged != nil {
+			for _, id := range caches.archivedIDs {
+				ap.archivedChanged[id] = struct{}{}
+			}
+		}
+	}
+	ap.mu.Unlock()
+
+	if caches.channelsChanged || caches.archivedChanged {
+		ap.persistChannelsCaches(caches.channelsChanged, caches.archivedChanged)
+	}
+}
+
+// persistChannelsCaches writes the current channels caches to their files.
+// Writes are ordered by cacheMu and each takes the latest maps, so a file
+// cannot go back in time
+func (ap *ApiProvider) persistChannelsCaches(channels, archived bool) {
+	ap.cacheMu.Lock()
+	defer ap.cacheMu.Unlock()
+
+	ap.mu.RLock()
+	channelsMap, archivedMap := ap.channels, ap.archivedChannels
+	ap.mu.RUnlock()
+
+	if channels {
+		ap.writeChannelsCache(channelsSlice(channelsMap))
+	}
+	if archived {
+		ap.writeArchivedChannelsCache(archivedMap)
+	}
+}
+
+func (c *channelsCaches) upsertArchived(ch Channel) Channel {
+	if prev, ok := c.channels[ch.ID]; ok {
+		if ch.MemberCount == 0 {
+			ch.MemberCount = prev.MemberCount
+		}
+		delete(c.channels, prev.ID)
+		if c.channelsInv[prev.Name] == prev.ID {
+			delete(c.channelsInv, prev.Name)
+		}
+		c.channelsChanged = true
+	}
+	if prev, ok := c.archivedChannels[ch.ID]; ok && prev.Name != ch.Name {
+		delete(c.archivedChannelsInv, prev.Name)
+	}
+
+	c.archivedChannels[ch.ID] = ch
+	c.archivedChannelsInv[ch.Name] = ch.ID
+	c.archivedChanged = true
+	c.archivedIDs = append(c.archivedIDs, ch.ID)
+	return ch
+}
+
+func (c *channelsCaches) removeArchived(ch Channel) {
+	delete(c.archivedChannels, ch.ID)
+	if c.archivedChannelsInv[ch.Name] == ch.ID {
+		delete(c.archivedChannelsInv, ch.Name)
+	}
+	c.archivedChanged = true
+	c.archivedIDs = append(c.archivedIDs, ch.ID)
+}
+
+func channelsSlice(m map[string]Channel) []Channel {
+	channels := make([]Channel, 0, len(m))
+	for _, c := range m {
+		channels = append(channels, c)
+	}
+	return channels
+}
+
+func (ap *ApiProvider) writeChannelsCache(channels []Channel) {
+	if ap.channelsCache == "" {
+		return
+	}
 	if data, err := json.MarshalIndent(channels, "", "  "); err != nil {
 		ap.logger.Error("Failed to marshal channels for cache", zap.Error(err))
 	} else {
@@ -617,8 +1378,71 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 				zap.String("cache_file", ap.channelsCache))
 		}
 	}
+}
 
-	ap.channelsReady = true
+// RefreshUsergroups fetches user groups (subteams) together with their
+// members and replaces the in-memory cache.
+func (ap *ApiProvider) RefreshUsergroups(ctx context.Context) error {
+	groups, err := ap.client.GetUserGroupsContext(ctx,
+		slack.GetUserGroupsOptionIncludeUsers(true),
+		slack.GetUserGroupsOptionIncludeCount(true),
+	)
+	if err != nil {
+		ap.logger.Error("Failed to fetch user groups", zap.Error(err))
+		return err
+	}
+
+	usergroups := make(map[string]slack.UserGroup, len(groups))
+	usergroupsInv := make(map[string]string, len(groups))
+	for _, g := range groups {
+		usergroups[g.ID] = g
+		if g.Handle != "" {
+			usergroupsInv[g.Handle] = g.ID
+		}
+	}
+
+	ap.mu.Lock()
+	ap.usergroups = usergroups
+	ap.usergroupsInv = usergroupsInv
+	ap.usergroupsReady = true
+	ap.mu.Unlock()
+
+	ap.logger.Info("Cached user groups", zap.Int("count", len(groups)))
+
+	return nil
+}
+
+// RefreshEmoji fetches custom emoji of the workspace and names of the standard
+// emoji used to validate reactions
+func (ap *ApiProvider) RefreshEmoji(ctx context.Context) error {
+	resp, err := ap.client.EmojiList(ctx)
+	if err != nil {
+		ap.logger.Error("Failed to fetch emoji", zap.Error(err))
+		return err
+	}
+
+	standard := make(map[string]struct{})
+	for _, c := range resp.Categories {
+		for _, name := range c.EmojiNames {
+			standard[name] = struct{}{}
+		}
+	}
+
+	custom := resp.Emoji
+	if custom == nil {
+		custom = make(map[string]string)
+	}
+
+	ap.mu.Lock()
+	ap.emojiCustom = custom
+	ap.emojiStandard = standard
+	ap.emojiRefreshed = time.Now()
+	ap.mu.Unlock()
+
+	ap.logger.Info("Cached emoji",
+		zap.Int("custom", len(custom)),
+		zap.Int("standard", len(standard)),
+	)
 
 	return nil
 }
@@ -659,10 +1483,17 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 }
 
 func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string) []Channel {
+	chans, _ := ap.getChannelsType(ctx, channelType, true)
+	return chans
+}
+
+// getChannelsType lists channels of a type, the error is only returned when
+// nothing could be fetched, partial results are logged and kept
+func (ap *ApiProvider) getChannelsType(ctx context.Context, channelType string, excludeArchived bool) ([]Channel, error) {
 	params := &slack.GetConversationsParameters{
 		Types:           []string{channelType},
 		Limit:           999,
-		ExcludeArchived: true,
+		ExcludeArchived: excludeArchived,
 	}
 
 	var (
@@ -676,7 +1507,7 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 	for {
 		if err := ap.rateLimiter.Wait(ctx); err != nil {
 			ap.logger.Error("Rate limiter wait failed", zap.Error(err))
-			return nil
+			return nil, err
 		}
 
 		channels, nextcur, err = ap.client.GetConversationsContext(ctx, params)
@@ -686,6 +1517,9 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 		)
 		if err != nil {
 			ap.logger.Error("Failed to fetch channels", zap.Error(err))
+			if len(chans) == 0 {
+				return nil, err
+			}
 			break
 		}
 
@@ -704,6 +1538,7 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 				channel.IsPrivate,
 				ap.ProvideUsersMap().Users,
 			)
+			ch.IsArchived = channel.IsArchived
 			chans = append(chans, ch)
 		}
 
@@ -713,7 +1548,7 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 
 		params.Cursor = nextcur
 	}
-	return chans
+	return chans, nil
 }
 
 func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) []Channel {
@@ -727,14 +1562,18 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 		chans = append(chans, typeChannels...)
 	}
 
+	ap.mu.Lock()
+	channels, channelsInv := maps.Clone(ap.channels), maps.Clone(ap.channelsInv)
 	for _, ch := range chans {
-		ap.channels[ch.ID] = ch
-		ap.channelsInv[ch.Name] = ch.ID
+		channels[ch.ID] = ch
+		channelsInv[ch.Name] = ch.ID
 	}
+	ap.channels, ap.channelsInv = channels, channelsInv
+	ap.mu.Unlock()
 
 	var res []Channel
 	for _, t := range channelTypes {
-		for _, channel := range ap.channels {
+		for _, channel := range channels {
 			if t == "public_channel" && !channel.IsPrivate {
 				res = append(res, channel)
 			}
@@ -760,14 +1599,84 @@ func (ap *ApiProvider) ProvideUsersMap() *UsersCache {
 	}
 }
 
+func (ap *ApiProvider) ProvideUsergroupsMap() *UsergroupsCache {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return &UsergroupsCache{
+		Usergroups:    ap.usergroups,
+		UsergroupsInv: ap.usergroupsInv,
+	}
+}
+
+func (ap *ApiProvider) IsUsergroupsReady() bool {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return ap.usergroupsReady
+}
+
+func (ap *ApiProvider) ProvideEmojiMap() *EmojiCache {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return &EmojiCache{
+		Custom:   ap.emojiCustom,
+		Standard: ap.emojiStandard,
+	}
+}
+
+// EmojiRefreshedAt returns when the emoji were cached, zero if they never were
+func (ap *ApiProvider) EmojiRefreshedAt() time.Time {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return ap.emojiRefreshed
+}
+
 func (ap *ApiProvider) ProvideChannelsMaps() *ChannelsCache {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
 	return &ChannelsCache{
 		Channels:    ap.channels,
 		ChannelsInv: ap.channelsInv,
 	}
 }
 
+// ProvideArchivedChannelsMaps returns the archived channels, they are cached
+// in the background after the active channels and may not be ready yet.
+func (ap *ApiProvider) ProvideArchivedChannelsMaps() *ChannelsCache {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return &ChannelsCache{
+		Channels:    ap.archivedChannels,
+		ChannelsInv: ap.archivedChannelsInv,
+	}
+}
+
+func (ap *ApiProvider) IsArchivedChannelsReady() bool {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	return ap.archivedChannelsReady
+}
+
+// LookupChannel resolves a #name or @name to a cached channel, falling back to
+// archived channels.
+func (ap *ApiProvider) LookupChannel(name string) (Channel, bool) {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+	if id, ok := ap.channelsInv[name]; ok {
+		if c, ok := ap.channels[id]; ok {
+			return c, true
+		}
+	}
+	if id, ok := ap.archivedChannelsInv[name]; ok {
+		if c, ok := ap.archivedChannels[id]; ok {
+			return c, true
+		}
+	}
+	return Channel{}, false
+}
+
 func (ap *ApiProvider) IsReady() (bool, error) {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
 	if !ap.usersReady {
 		return false, ErrUsersNotReady
 	}
@@ -790,6 +1699,16 @@ func (ap *ApiProvider) IsBotToken() bool {
 	return ok && client != nil && client.IsBotToken()
 }
 
+func (ap *ApiProvider) IsEnterprise() bool {
+	client, ok := ap.client.(*MCPSlackClient)
+	return ok && client != nil && client.IsEnterprise()
+}
+
+func (ap *ApiProvider) IsOAuth() bool {
+	client, ok := ap.client.(*MCPSlackClient)
+	return ok && client != nil && client.IsOAuth()
+}
+
 func mapChannel(
 	id, name, nameNormalized, topic, purpose, user string,
 	members []string,
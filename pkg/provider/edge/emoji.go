@@ -0,0 +1,56 @@
+package edge
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// emoji.* API
+
+type emojiListForm struct {
+	BaseRequest
+	IncludeCategories bool `json:"include_categories"`
+	WebClientFields
+}
+
+// EmojiCategory is a category of standard (unicode) emoji as returned by
+// emoji.list with include_categories.
+type EmojiCategory struct {
+	Name       string   `json:"name"`
+	EmojiNames []string `json:"emoji_names"`
+}
+
+// EmojiListResponse is the response of emoji.list.  Emoji maps custom emoji
+// names to their image URL or to "alias:<name>" for aliases.
+type EmojiListResponse struct {
+	baseResponse
+	Emoji             map[string]string `json:"emoji"`
+	Categories        []EmojiCategory   `json:"categories,omitempty"`
+	CategoriesVersion string            `json:"categories_version,omitempty"`
+}
+
+// EmojiList returns custom emoji of the workspace together with the names of
+// standard emoji grouped by category.
+func (cl *Client) EmojiList(ctx context.Context) (*EmojiListResponse, error) {
+	ctx, task := trace.NewTask(ctx, "EmojiList")
+	defer task.End()
+
+	form := emojiListForm{
+		BaseRequest:       BaseRequest{Token: cl.token},
+		IncludeCategories: true,
+		WebClientFields:   webclientReason("emoji-list"),
+	}
+
+	resp, err := cl.PostForm(ctx, "emoji.list", values(form, true))
+	if err != nil {
+		return nil, err
+	}
+	var r EmojiListResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return nil, err
+	}
+	if err := r.validate("emoji.list"); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,110 @@
+package edge
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// saved.* API
+
+type savedListForm struct {
+	BaseRequest
+	Filter string `json:"filter,omitempty"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor,omitempty"`
+	WebClientFields
+}
+
+// SavedItem is an item of the "Saved for later" list.  ItemID is the
+// channel of a saved message or the ID of a saved file.
+type SavedItem struct {
+	ItemID        string `json:"item_id"`
+	ItemType      string `json:"item_type"`
+	TS            string `json:"ts,omitempty"`
+	State         string `json:"state,omitempty"`
+	DateCreated   int64  `json:"date_created"`
+	DateDue       int64  `json:"date_due"`
+	DateCompleted int64  `json:"date_completed"`
+	IsArchived    bool   `json:"is_archived"`
+}
+
+type SavedListResponse struct {
+	baseResponse
+	SavedItems []SavedItem `json:"saved_items"`
+}
+
+// SavedList returns one page of the "Saved for later" list.  filter is one
+// of "saved" (in progress), "completed" or "archived", an empty filter
+// returns items in progress.
+func (cl *Client) SavedList(ctx context.Context, filter, cursor string, limit int) (*SavedListResponse, error) {
+	ctx, task := trace.NewTask(ctx, "SavedList")
+	defer task.End()
+	trace.Logf(ctx, "params", "filter=%s, cursor=%s, limit=%d", filter, cursor, limit)
+
+	form := savedListForm{
+		BaseRequest:     BaseRequest{Token: cl.token},
+		Filter:          filter,
+		Limit:           limit,
+		Cursor:          cursor,
+		WebClientFields: webclientReason("saved-api/savedList"),
+	}
+
+	resp, err := cl.PostForm(ctx, "saved.list", values(form, true))
+	if err != nil {
+		return nil, err
+	}
+	var r SavedListResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return nil, err
+	}
+	if err := r.validate("saved.list"); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+type savedItemForm struct {
+	BaseRequest
+	ItemType string `json:"item_type"`
+	ItemID   string `json:"item_id"`
+	TS       string `json:"ts,omitempty"`
+	WebClientFields
+}
+
+type savedItemResponse struct {
+	baseResponse
+}
+
+// SavedAdd saves the message ts of the channel for later.
+func (cl *Client) SavedAdd(ctx context.Context, channelID, ts string) error {
+	return cl.savedItem(ctx, "saved.add", channelID, ts)
+}
+
+// SavedDelete removes the message ts of the channel from the saved items.
+func (cl *Client) SavedDelete(ctx context.Context, channelID, ts string) error {
+	return cl.savedItem(ctx, "saved.delete", channelID, ts)
+}
+
+func (cl *Client) savedItem(ctx context.Context, endpoint, channelID, ts string) error {
+	ctx, task := trace.NewTask(ctx, endpoint)
+	defer task.End()
+	trace.Logf(ctx, "params", "channelID=%s, ts=%s", channelID, ts)
+
+	form := savedItemForm{
+		BaseRequest:     BaseRequest{Token: cl.token},
+		ItemType:        "message",
+		ItemID:          channelID,
+		TS:              ts,
+		WebClientFields: webclientReason("saved-api/" + endpoint),
+	}
+
+	resp, err := cl.PostForm(ctx, endpoint, values(form, true))
+	if err != nil {
+		return err
+	}
+	var r savedItemResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return err
+	}
+	return r.validate(endpoint)
+}
@@ -0,0 +1,48 @@
+package edge
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// subscriptions.* API
+
+type subscriptionsThreadMarkForm struct {
+	BaseRequest
+	Channel  string `json:"channel"`
+	ThreadTS string `json:"thread_ts"`
+	TS       string `json:"ts"`
+	Read     int    `json:"read"`
+	WebClientFields
+}
+
+type subscriptionsThreadMarkResponse struct {
+	baseResponse
+}
+
+// SubscriptionsThreadMark marks the thread threadTS in the channel as read up
+// to and including the reply ts.
+func (cl *Client) SubscriptionsThreadMark(ctx context.Context, channelID, threadTS, ts string) error {
+	ctx, task := trace.NewTask(ctx, "SubscriptionsThreadMark")
+	defer task.End()
+	trace.Logf(ctx, "params", "channelID=%s, threadTS=%s, ts=%s", channelID, threadTS, ts)
+
+	form := subscriptionsThreadMarkForm{
+		BaseRequest:     BaseRequest{Token: cl.token},
+		Channel:         channelID,
+		ThreadTS:        threadTS,
+		TS:              ts,
+		Read:            1,
+		WebClientFields: webclientReason("thread-mark-read"),
+	}
+
+	resp, err := cl.PostForm(ctx, "subscriptions.thread.mark", values(form, true))
+	if err != nil {
+		return err
+	}
+	var r subscriptionsThreadMarkResponse
+	if err := cl.ParseResponse(&r, resp); err != nil {
+		return err
+	}
+	return r.validate("subscriptions.thread.mark")
+}
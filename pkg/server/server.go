@@ -29,14 +29,16 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		server.WithRecovery(),
 		server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
 		server.WithToolHandlerMiddleware(auth.BuildMiddleware(provider.ServerTransport(), logger)),
+		server.WithToolHandlerMiddleware(buildOutputFormatMiddleware()),
 	)
 
 	conversationsHandler := handler.NewConversationsHandler(provider, logger)
 
-	s.AddTool(mcp.NewTool("conversations_history",
+	s.AddTool(newTool("conversations_history",
 		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
 		mcp.WithTitleAnnotation("Get Conversation History"),
 		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOutputSchema[handler.RowsResult[handler.Message]](),
 		mcp.WithString("channel_id",
 			mcp.Required(),
 			mcp.Description("    - `channel_id` (string): ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
@@ -45,6 +47,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("mark_read",
+			mcp.Description("If true, the conversation is marked as read up to the newest returned message. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
@@ -54,10 +60,11 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 	), conversationsHandler.ConversationsHistoryHandler)
 
-	s.AddTool(mcp.NewTool("conversations_replies",
+	s.AddTool(newTool("conversations_replies",
 		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
 		mcp.WithTitleAnnotation("Get Thread Replies"),
 		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOutputSchema[handler.RowsResult[handler.Message]](),
 		mcp.WithString("channel_id",
 			mcp.Required(),
 			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
@@ -70,6 +77,10 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("mark_read",
+			mcp.Description("If true, the thread is marked as read up to the newest returned reply. Only supported with browser tokens (xoxc/xoxd). Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
 		mcp.WithString("cursor",
 			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
 		),
@@ -79,27 +90,150 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 	), conversationsHandler.ConversationsRepliesHandler)
 
-	s.AddTool(mcp.NewTool("conversations_add_message",
+	s.AddTool(newTool("conversations_context",
+		mcp.WithDescription("Get a message by its permalink or channel_id and timestamp together with its thread parent and replies and the messages posted before and after it in the channel, in reading order. The 'position' column tells the linked message apart from its context."),
+		mcp.WithTitleAnnotation("Get Message Context"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("permalink",
+			mcp.Description("Link to the message, e.g. 'https://example.slack.com/archives/C1234567890/p1234567890123456'. Takes precedence over channel_id and timestamp."),
+		),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. Required when permalink is not provided."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Description("Timestamp of the message in format 1234567890.123456. Required when permalink is not provided."),
+		),
+		mcp.WithNumber("context_size",
+			mcp.DefaultNumber(5),
+			mcp.Description("Number of channel messages to fetch before and after the message, or its thread parent for replies, between 0 and 50."),
+		),
+		mcp.WithNumber("replies_limit",
+			mcp.DefaultNumber(50),
+			mcp.Description("Maximum number of thread replies to fetch, between 1 and 200."),
+		),
+		mcp.WithBoolean("include_activity_messages",
+			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+	), conversationsHandler.ConversationsContextHandler)
+
+	s.AddTool(newTool("conversations_mark",
+		mcp.WithDescription("Mark a public channel, private channel, direct message (DM, or IM) conversation or a thread as read up to a given message, clearing its unread badge."),
+		mcp.WithTitleAnnotation("Mark Conversation Read"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("ts",
+			mcp.DefaultString("latest"),
+			mcp.Description("Timestamp of the message in format 1234567890.123456 to mark as read up to, or 'latest' for the newest message. Default is 'latest'."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of the thread's parent message in format 1234567890.123456 to mark the thread instead of the channel. Only supported with browser tokens (xoxc/xoxd)."),
+		),
+	), conversationsHandler.ConversationsMarkHandler)
+
+	s.AddTool(newTool("conversations_add_message",
 		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
 		mcp.WithTitleAnnotation("Send Message"),
 		mcp.WithDestructiveHintAnnotation(true),
 		mcp.WithString("channel_id",
 			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. A DM or group DM that does not exist yet is opened on the fly, e.g. '@alice' or '@alice,@bob'."),
 		),
 		mcp.WithString("thread_ts",
 			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread."),
 		),
 		mcp.WithString("payload",
-			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain, '# Hello, world!' for text/markdown or '[{\"type\": \"section\", \"text\": {\"type\": \"mrkdwn\", \"text\": \"*Deploy* succeeded\"}}]' for application/vnd.slack.blocks+json."),
 		),
 		mcp.WithString("content_type",
 			mcp.DefaultString("text/markdown"),
-			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain', 'application/vnd.slack.blocks+json' for a Block Kit JSON array of blocks (or an object with 'blocks' and a notification 'text'), 'application/vnd.slack.attachments+json' for a JSON array of legacy attachments (or an object with 'attachments' and 'text'). Block Kit payloads are validated before posting: at most 50 blocks and 3000 characters per section text."),
+		),
+		mcp.WithString("post_at",
+			mcp.Description("Schedule the message instead of posting it immediately. Accepts a unix timestamp, RFC3339, a date (e.g. 'tomorrow', '2025-07-15', 'Friday') optionally followed by a time of day (e.g. 'tomorrow 9am', 'Friday at 14:30'), or a bare time of day (e.g. '17:00'). Dates and times are interpreted in the authenticated user's time zone. Must be in the future and at most 120 days ahead."),
 		),
 	), conversationsHandler.ConversationsAddMessageHandler)
 
-	s.AddTool(mcp.NewTool("reactions_add",
+	s.AddTool(newTool("conversations_open",
+		mcp.WithDescription("Open a direct message (DM, or IM) with one user or a group DM (MPIM) with several users, or return the existing one. The returned ID can be used as channel_id of other tools."),
+		mcp.WithTitleAnnotation("Open Conversation"),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of at most 8 users, each as ID in format Uxxxxxxxxxx or username starting with @... aka @john. Example: '@alice' or 'U1234567890,@bob'."),
+		),
+	), conversationsHandler.ConversationsOpenHandler)
+
+	s.AddTool(newTool("conversations_scheduled_list",
+		mcp.WithDescription("List pending scheduled messages, optionally limited to a single channel. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("List Scheduled Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Description("Optional ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of scheduled messages to return, between 1 and 1000."),
+		),
+	), conversationsHandler.ConversationsScheduledListHandler)
+
+	s.AddTool(newTool("conversations_scheduled_cancel",
+		mcp.WithDescription("Cancel a pending scheduled message by channel_id and scheduled_message_id."),
+		mcp.WithTitleAnnotation("Cancel Scheduled Message"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("scheduled_message_id",
+			mcp.Required(),
+			mcp.Description("ID of the scheduled message as returned by conversations_add_message with post_at or by conversations_scheduled_list."),
+		),
+	), conversationsHandler.ConversationsScheduledCancelHandler)
+
+	s.AddTool(newTool("conversations_update_message",
+		mcp.WithDescription("Edit a message previously posted to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and timestamp. By default only messages posted by the authenticated user can be edited."),
+		mcp.WithTitleAnnotation("Edit Message"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to edit, in format 1234567890.123456."),
+		),
+		mcp.WithString("payload",
+			mcp.Required(),
+			mcp.Description("New message payload in specified content_type format. Example: 'Hello, world!' for text/plain, '# Hello, world!' for text/markdown or '[{\"type\": \"section\", \"text\": {\"type\": \"mrkdwn\", \"text\": \"*Deploy* succeeded\"}}]' for application/vnd.slack.blocks+json."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain', 'application/vnd.slack.blocks+json' for a Block Kit JSON array of blocks (or an object with 'blocks' and a notification 'text'), 'application/vnd.slack.attachments+json' for a JSON array of legacy attachments (or an object with 'attachments' and 'text'). Block Kit payloads are validated before posting: at most 50 blocks and 3000 characters per section text."),
+		),
+	), conversationsHandler.ConversationsUpdateMessageHandler)
+
+	s.AddTool(newTool("conversations_delete_message",
+		mcp.WithDescription("Delete a message from a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and timestamp. By default only messages posted by the authenticated user can be deleted."),
+		mcp.WithTitleAnnotation("Delete Message"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to delete, in format 1234567890.123456."),
+		),
+	), conversationsHandler.ConversationsDeleteMessageHandler)
+
+	s.AddTool(newTool("reactions_add",
 		mcp.WithDescription("Add an emoji reaction to a message in a public channel, private channel, or direct message (DM, or IM) conversation."),
 		mcp.WithDestructiveHintAnnotation(true),
 		mcp.WithString("channel_id",
@@ -112,11 +246,11 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 		mcp.WithString("emoji",
 			mcp.Required(),
-			mcp.Description("The name of the emoji to add as a reaction (without colons). Example: 'thumbsup', 'heart', 'rocket'."),
+			mcp.Description("The name of the emoji to add as a reaction, with or without colons, or the unicode emoji itself. Skin tones use the 'name::skin-tone-N' form. Example: 'thumbsup', ':heart:', '🚀'. Use emoji_list to look up custom emoji."),
 		),
 	), conversationsHandler.ReactionsAddHandler)
 
-	s.AddTool(mcp.NewTool("reactions_remove",
+	s.AddTool(newTool("reactions_remove",
 		mcp.WithDescription("Remove an emoji reaction from a message in a public channel, private channel, or direct message (DM, or IM) conversation."),
 		mcp.WithDestructiveHintAnnotation(true),
 		mcp.WithString("channel_id",
@@ -129,24 +263,223 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 		mcp.WithString("emoji",
 			mcp.Required(),
-			mcp.Description("The name of the emoji to remove as a reaction (without colons). Example: 'thumbsup', 'heart', 'rocket'."),
+			mcp.Description("The name of the emoji to remove as a reaction, with or without colons, or the unicode emoji itself. Skin tones use the 'name::skin-tone-N' form. Example: 'thumbsup', ':heart:', '🚀'. Use emoji_list to look up custom emoji."),
 		),
 	), conversationsHandler.ReactionsRemoveHandler)
 
+	s.AddTool(newTool("reactions_get",
+		mcp.WithDescription("Get every emoji reaction on a message with the users who reacted, e.g. to see who approved or voted."),
+		mcp.WithTitleAnnotation("Get Reactions"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message, in format 1234567890.123456."),
+		),
+	), conversationsHandler.ReactionsGetHandler)
+
+	s.AddTool(newTool("reactions_list",
+		mcp.WithDescription("Get messages a user reacted to with the emoji of their reactions, newest reactions first. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("List Reacted Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("user",
+			mcp.Description("User whose reactions to list, as ID in format Uxxxxxxxxxx, username starting with @... aka @john, or email address. Defaults to the authenticated user."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Only messages posted after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Only messages posted before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The number of reacted items to fetch per page, between 1 and 100. Fewer rows are returned when date filters skip items."),
+		),
+	), conversationsHandler.ReactionsListHandler)
+
+	s.AddTool(newTool("pins_list",
+		mcp.WithDescription("Get messages and files pinned to a public channel, private channel, or direct message (DM, or IM) conversation. Pinned items usually hold runbooks and decisions of the team."),
+		mcp.WithTitleAnnotation("List Pinned Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+	), conversationsHandler.PinsListHandler)
+
+	s.AddTool(newTool("pins_add",
+		mcp.WithDescription("Pin a message to a public channel, private channel, or direct message (DM, or IM) conversation."),
+		mcp.WithTitleAnnotation("Pin Message"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to pin, in format 1234567890.123456."),
+		),
+	), conversationsHandler.PinsAddHandler)
+
+	s.AddTool(newTool("pins_remove",
+		mcp.WithDescription("Unpin a message from a public channel, private channel, or direct message (DM, or IM) conversation."),
+		mcp.WithTitleAnnotation("Unpin Message"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to unpin, in format 1234567890.123456."),
+		),
+	), conversationsHandler.PinsRemoveHandler)
+
+	s.AddTool(newTool("bookmarks_list",
+		mcp.WithDescription("Get bookmarks of a public channel, private channel, or direct message (DM, or IM) conversation."),
+		mcp.WithTitleAnnotation("List Bookmarks"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+	), conversationsHandler.BookmarksListHandler)
+
+	s.AddTool(newTool("bookmarks_add",
+		mcp.WithDescription("Add a link bookmark to a public channel, private channel, or direct message (DM, or IM) conversation."),
+		mcp.WithTitleAnnotation("Add Bookmark"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Title of the bookmark. Example: 'On-call runbook'."),
+		),
+		mcp.WithString("link",
+			mcp.Required(),
+			mcp.Description("Absolute http(s) URL the bookmark points to. Example: 'https://wiki.example.com/runbook'."),
+		),
+		mcp.WithString("emoji",
+			mcp.Description("Optional emoji shown next to the bookmark, with or without colons. Example: 'book'."),
+		),
+	), conversationsHandler.BookmarksAddHandler)
+
+	s.AddTool(newTool("bookmarks_remove",
+		mcp.WithDescription("Remove a bookmark from a public channel, private channel, or direct message (DM, or IM) conversation."),
+		mcp.WithTitleAnnotation("Remove Bookmark"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("bookmark_id",
+			mcp.Required(),
+			mcp.Description("ID of the bookmark as returned by bookmarks_list, in format Btxxxxxxxxxx."),
+		),
+	), conversationsHandler.BookmarksRemoveHandler)
+
+	// Saved items are personal to a user, they are not available for bot tokens
+	if !provider.IsBotToken() {
+		s.AddTool(newTool("saved_list",
+			mcp.WithDescription("Get messages and files the authenticated user saved for later (starred items for OAuth tokens) with their text, channel, author, save date and due date. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+			mcp.WithTitleAnnotation("List Saved Items"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("filter",
+				mcp.DefaultString("in_progress"),
+				mcp.Description("Which saved items to return: 'in_progress', 'completed' or 'archived'. Only supported with browser tokens (xoxc/xoxd), OAuth tokens return starred items."),
+			),
+			mcp.WithNumber("limit",
+				mcp.DefaultNumber(20),
+				mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+			),
+			mcp.WithString("cursor",
+				mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+			),
+		), conversationsHandler.SavedListHandler)
+
+		s.AddTool(newTool("saved_add",
+			mcp.WithDescription("Save a message for later (star it for OAuth tokens)."),
+			mcp.WithTitleAnnotation("Save Message"),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("channel_id",
+				mcp.Required(),
+				mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+			),
+			mcp.WithString("timestamp",
+				mcp.Required(),
+				mcp.Description("Timestamp of the message to save, in format 1234567890.123456."),
+			),
+		), conversationsHandler.SavedAddHandler)
+
+		s.AddTool(newTool("saved_remove",
+			mcp.WithDescription("Remove a message from the items saved for later (unstar it for OAuth tokens)."),
+			mcp.WithTitleAnnotation("Unsave Message"),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithString("channel_id",
+				mcp.Required(),
+				mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+			),
+			mcp.WithString("timestamp",
+				mcp.Required(),
+				mcp.Description("Timestamp of the saved message, in format 1234567890.123456."),
+			),
+		), conversationsHandler.SavedRemoveHandler)
+	}
+
 	s.AddTool(mcp.NewTool("attachment_get_data",
 		mcp.WithDescription("Download an attachment's content by file ID. Returns file metadata and content (text files as-is, binary files as base64). Maximum file size is 5MB."),
 		mcp.WithTitleAnnotation("Get Attachment Data"),
 		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOutputSchema[handler.FileData](),
 		mcp.WithString("file_id",
 			mcp.Required(),
-			mcp.Description("The ID of the attachment to download, in format Fxxxxxxxxxx. Attachment IDs can be found in message metadata when HasMedia is true or AttachmentCount > 0."),
+			mcp.Description("The ID of the attachment to download, in format Fxxxxxxxxxx. Attachment IDs can be found in message metadata when HasMedia is true or AttachmentCount > 0, or in the results of files_search and files_list."),
 		),
 	), conversationsHandler.FilesGetHandler)
 
-	conversationsSearchTool := mcp.NewTool("conversations_search_messages",
+	s.AddTool(mcp.NewTool("attachment_upload",
+		mcp.WithDescription("Upload a file to a public channel, private channel, or direct message (DM, or IM) conversation, optionally into a thread. Accepts either text content or base64 encoded bytes. Maximum file size is 5MB."),
+		mcp.WithTitleAnnotation("Upload Attachment"),
+		mcp.WithOutputSchema[handler.UploadedFile](),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of the thread's parent message in format 1234567890.123456. Optional, if not provided the file will be shared to the channel itself."),
+		),
+		mcp.WithString("filename",
+			mcp.Required(),
+			mcp.Description("Name of the file including extension, e.g. 'incident.log' or 'report.csv'."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the file. Defaults to the filename."),
+		),
+		mcp.WithString("content",
+			mcp.Description("Text content of the file. Mutually exclusive with content_base64."),
+		),
+		mcp.WithString("content_base64",
+			mcp.Description("Base64 encoded binary content of the file. Mutually exclusive with content."),
+		),
+		mcp.WithString("initial_comment",
+			mcp.Description("Optional message text to post along with the file."),
+		),
+	), conversationsHandler.FilesUploadHandler)
+
+	conversationsSearchTool := newTool("conversations_search_messages",
 		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
 		mcp.WithTitleAnnotation("Search Messages"),
 		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOutputSchema[handler.RowsResult[handler.Message]](),
 		mcp.WithString("search_query",
 			mcp.Description("Search query to filter messages. Example: 'marketing report' or full URL of Slack message e.g. 'https://slack.com/archives/C1234567890/p1234567890123456', then the tool will return a single message matching given URL, herewith all other parameters will be ignored."),
 		),
@@ -191,15 +524,115 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		s.AddTool(conversationsSearchTool, conversationsHandler.ConversationsSearchHandler)
 	}
 
+	filesSearchTool := newTool("files_search",
+		mcp.WithDescription("Search files shared in public channels, private channels, and direct message (DM, or IM) conversations using filters. All filters are optional, if not provided then search_query is required. The fileID column can be passed to attachment_get_data. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("Search Files"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("search_query",
+			mcp.Description("Search query matched against file names, titles and contents. Example: 'quarterly report' or 'roadmap.pdf'."),
+		),
+		mcp.WithString("filter_in_channel",
+			mcp.Description("Filter files shared in a specific public/private channel by its ID or name. Example: 'C1234567890', 'G1234567890', or '#general'. If not provided, all channels will be searched."),
+		),
+		mcp.WithString("filter_in_im_or_mpim",
+			mcp.Description("Filter files shared in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
+		),
+		mcp.WithString("filter_users_with",
+			mcp.Description("Filter files shared with a specific user by their ID or display name in threads and DMs. Example: 'U1234567890' or '@username'."),
+		),
+		mcp.WithString("filter_users_from",
+			mcp.Description("Filter files shared by a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Filter files shared before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Filter files shared after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_on",
+			mcp.Description("Filter files shared on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_during",
+			mcp.Description("Filter files shared during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("cursor",
+			mcp.DefaultString(""),
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+	)
+	// Only register file search tool for non-bot tokens (bot tokens cannot use search.files API)
+	if !provider.IsBotToken() {
+		s.AddTool(filesSearchTool, conversationsHandler.FilesSearchHandler)
+	}
+
+	s.AddTool(newTool("files_list",
+		mcp.WithDescription("List files, newest first, optionally narrowed down to a channel, a user, file types and a date range. The fileID column can be passed to attachment_get_data. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("List Files"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. If not provided, files of all accessible conversations are listed."),
+		),
+		mcp.WithString("user",
+			mcp.Description("Only list files created by this user, by ID or name. Example: 'U1234567890' or '@username'."),
+		),
+		mcp.WithString("types",
+			mcp.Description("Comma-separated list of file types: all, spaces, snippets, images, gdocs, zips, pdfs. Default is all."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Only list files created before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Only list files created after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'."),
+		),
+		mcp.WithString("cursor",
+			mcp.DefaultString(""),
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+	), conversationsHandler.FilesListHandler)
+
+	conversationsUnreadsTool := newTool("conversations_unreads",
+		mcp.WithDescription("Get list of channels, DMs and group DMs with unread messages and mention counts, sorted by mentions first. Optionally returns the unread messages themselves as a second CSV."),
+		mcp.WithTitleAnnotation("Get Unread Conversations"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_types",
+			mcp.Description("Comma-separated channel types to include. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. If not provided, all types are included."),
+		),
+		mcp.WithBoolean("mentions_only",
+			mcp.Description("If true, only conversations where the user has been mentioned are returned. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_messages",
+			mcp.Description("If true, the unread messages (everything after the last read mark) are fetched for each conversation and returned as a second CSV. Conversations which were never read return only their latest 5 messages. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("messages_limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of unread messages to fetch per conversation when include_messages is true. Must be an integer between 1 and 100."),
+		),
+	)
+	// Only register unreads tool for browser tokens (client.counts API is not available for OAuth tokens)
+	if !provider.IsOAuth() {
+		s.AddTool(conversationsUnreadsTool, conversationsHandler.ConversationsUnreadsHandler)
+	}
+
 	channelsHandler := handler.NewChannelsHandler(provider, logger)
 
-	s.AddTool(mcp.NewTool("channels_list",
+	s.AddTool(newTool("channels_list",
 		mcp.WithDescription("Get list of channels"),
 		mcp.WithTitleAnnotation("List Channels"),
 		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithOutputSchema[handler.RowsResult[handler.Channel]](),
 		mcp.WithString("channel_types",
 			mcp.Required(),
-			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'public_channel,private_channel,im'"),
+			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel', 'archived'. 'archived' selects archived public and private channels, which are otherwise excluded. Example: 'public_channel,private_channel,im'"),
 		),
 		mcp.WithString("sort",
 			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort by number of members/participants in each channel."),
@@ -213,6 +646,228 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		),
 	), channelsHandler.ChannelsHandler)
 
+	s.AddTool(newTool("channels_info",
+		mcp.WithDescription("Get full metadata of a channel: creator, creation date, topic and purpose with who set them and when, archived and shared flags. Members resolved to user names are returned as a second CSV."),
+		mcp.WithTitleAnnotation("Get Channel Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithBoolean("include_members",
+			mcp.Description("If true, the members of the channel are returned as a second CSV. Default is boolean true."),
+			mcp.DefaultBool(true),
+		),
+	), channelsHandler.ChannelsInfoHandler)
+
+	s.AddTool(newTool("channels_create",
+		mcp.WithDescription("Create a public or private channel, optionally with a topic and purpose. The new channel can be referenced by its #name in other tools right away."),
+		mcp.WithTitleAnnotation("Create Channel"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the channel, lowercase without spaces or periods and at most 80 characters. Example: 'project-apollo'."),
+		),
+		mcp.WithBoolean("is_private",
+			mcp.Description("If true, a private channel is created. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("topic",
+			mcp.Description("Topic of the channel, at most 250 characters."),
+		),
+		mcp.WithString("purpose",
+			mcp.Description("Purpose of the channel, at most 250 characters."),
+		),
+	), channelsHandler.ChannelsCreateHandler)
+
+	s.AddTool(newTool("channels_archive",
+		mcp.WithDescription("Archive a public or private channel."),
+		mcp.WithTitleAnnotation("Archive Channel"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+	), channelsHandler.ChannelsArchiveHandler)
+
+	s.AddTool(newTool("channels_unarchive",
+		mcp.WithDescription("Unarchive a public or private channel."),
+		mcp.WithTitleAnnotation("Unarchive Channel"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+	), channelsHandler.ChannelsUnarchiveHandler)
+
+	s.AddTool(newTool("channels_invite",
+		mcp.WithDescription("Invite one or more users to a public or private channel."),
+		mcp.WithTitleAnnotation("Invite Users to Channel"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of users to invite, each as ID in format Uxxxxxxxxxx, username starting with @... aka @john, or email address."),
+		),
+	), channelsHandler.ChannelsInviteHandler)
+
+	s.AddTool(newTool("channels_kick",
+		mcp.WithDescription("Remove a user from a public or private channel."),
+		mcp.WithTitleAnnotation("Remove User from Channel"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User to remove as ID in format Uxxxxxxxxxx, username starting with @... aka @john, or email address."),
+		),
+	), channelsHandler.ChannelsKickHandler)
+
+	s.AddTool(newTool("channels_set_topic",
+		mcp.WithDescription("Set the topic of a public or private channel."),
+		mcp.WithTitleAnnotation("Set Channel Topic"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("topic",
+			mcp.Required(),
+			mcp.Description("New topic of the channel, at most 250 characters. An empty string clears the topic."),
+		),
+	), channelsHandler.ChannelsSetTopicHandler)
+
+	s.AddTool(newTool("channels_set_purpose",
+		mcp.WithDescription("Set the purpose of a public or private channel."),
+		mcp.WithTitleAnnotation("Set Channel Purpose"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("purpose",
+			mcp.Required(),
+			mcp.Description("New purpose of the channel, at most 250 characters. An empty string clears the purpose."),
+		),
+	), channelsHandler.ChannelsSetPurposeHandler)
+
+	usersHandler := handler.NewUsersHandler(provider, logger)
+
+	s.AddTool(newTool("users_search",
+		mcp.WithDescription("Search users in the workspace directory by name, display name, email, title or timezone. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("Search Users"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("query",
+			mcp.Description("Case-insensitive text matched against username, real name, display name and email. Example: 'john' or 'john@example.com'."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Case-insensitive text matched against the profile title. Example: 'engineer'."),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("Case-insensitive text matched against the timezone identifier or label. Example: 'Europe/Berlin' or 'Pacific'."),
+		),
+		mcp.WithBoolean("include_bots",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, bot users are included in the results."),
+		),
+		mcp.WithBoolean("include_deleted",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, deactivated users are included in the results."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000 (maximum 999)."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+	), usersHandler.UsersSearchHandler)
+
+	s.AddTool(newTool("users_get",
+		mcp.WithDescription("Get the full profile of a user: title, timezone, status, bot, restricted (guest) and deactivated flags."),
+		mcp.WithTitleAnnotation("Get User"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("ID of the user in format Uxxxxxxxxxx, their username starting with @... aka @john, or their email address."),
+		),
+	), usersHandler.UsersGetHandler)
+
+	s.AddTool(newTool("users_presence",
+		mcp.WithDescription("Get presence (active/away), Do Not Disturb window and custom status with emoji and expiration of one or more users. Useful to check whether someone is away before pinging them."),
+		mcp.WithTitleAnnotation("Get User Presence"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("users",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of up to 50 users, each as ID in format Uxxxxxxxxxx, username starting with @... aka @john, or email address."),
+		),
+	), usersHandler.UsersPresenceHandler)
+
+	usersSetStatusTool := newTool("users_set_status",
+		mcp.WithDescription("Set or clear the custom status of the authenticated user, e.g. 'Focus time' with :headphones: for 2 hours. Calling it with empty status_text and status_emoji clears the status."),
+		mcp.WithTitleAnnotation("Set Custom Status"),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("status_text",
+			mcp.Description("Status text of at most 100 characters. Example: 'Focus time'."),
+		),
+		mcp.WithString("status_emoji",
+			mcp.Description("Status emoji, with or without colons. Example: 'headphones'."),
+		),
+		mcp.WithString("expiration",
+			mcp.Description("When the status expires: a duration from now (e.g. '30m', '2h'), a unix timestamp, RFC3339, or a date and time of day (e.g. 'tomorrow 9am', '17:00') in the authenticated user's time zone. If not provided the status never expires."),
+		),
+	)
+	// Only register set status tool for user tokens (users.profile.set is not available for bot tokens)
+	if !provider.IsBotToken() {
+		s.AddTool(usersSetStatusTool, usersHandler.UsersSetStatusHandler)
+	}
+
+	s.AddTool(newTool("usergroups_list",
+		mcp.WithDescription("Get list of user groups (subteams) with their @handles, names, descriptions and member counts."),
+		mcp.WithTitleAnnotation("List User Groups"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("query",
+			mcp.Description("Optional case-insensitive text matched against handle, name and description. Example: 'oncall'."),
+		),
+	), usersHandler.UsergroupsListHandler)
+
+	s.AddTool(newTool("usergroups_members",
+		mcp.WithDescription("Get members of a user group (subteam) with their profiles."),
+		mcp.WithTitleAnnotation("List User Group Members"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("usergroup",
+			mcp.Required(),
+			mcp.Description("ID of the user group in format Sxxxxxxxxxx or its handle starting with @... aka @oncall-backend."),
+		),
+	), usersHandler.UsergroupsMembersHandler)
+
+	emojiHandler := handler.NewEmojiHandler(provider, logger)
+
+	s.AddTool(newTool("emoji_list",
+		mcp.WithDescription("List custom emoji of the workspace with their aliases and image URLs, and optionally standard emoji names. Use it to find valid names for reactions. The last row/column in the response is used as 'cursor' parameter for pagination if not empty."),
+		mcp.WithTitleAnnotation("List Emoji"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("query",
+			mcp.Description("Case-insensitive text matched against emoji names and alias targets. Example: 'party' or ':shipit:'."),
+		),
+		mcp.WithBoolean("include_standard",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, names of standard (unicode) emoji are included as well."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+	), emojiHandler.EmojiListHandler)
+
 	logger.Info("Authenticating with Slack API...",
 		zap.String("context", "console"),
 	)
@@ -245,14 +900,14 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		"slack://"+ws+"/channels",
 		"Directory of Slack channels",
 		mcp.WithResourceDescription("This resource provides a directory of Slack channels."),
-		mcp.WithMIMEType("text/csv"),
+		mcp.WithMIMEType(handler.ResourceMIMEType()),
 	), channelsHandler.ChannelsResource)
 
 	s.AddResource(mcp.NewResource(
 		"slack://"+ws+"/users",
 		"Directory of Slack users",
 		mcp.WithResourceDescription("This resource provides a directory of Slack users."),
-		mcp.WithMIMEType("text/csv"),
+		mcp.WithMIMEType(handler.ResourceMIMEType()),
 	), conversationsHandler.UsersResource)
 
 	return &MCPServer{
@@ -310,6 +965,28 @@ func (s *MCPServer) ServeStdio() error {
 	return err
 }
 
+// newTool creates a tool with the "format" argument shared by every tool
+// which renders rows or status messages
+func newTool(name string, opts ...mcp.ToolOption) mcp.Tool {
+	return mcp.NewTool(name, append(opts,
+		mcp.WithString("format",
+			mcp.Enum("csv", "json"),
+			mcp.Description("Output format of the result: 'csv' or 'json'. JSON returns an object with an 'items' array and a top-level 'next_cursor' instead of a cursor in the last row. Defaults to SLACK_MCP_OUTPUT_FORMAT or 'csv'."),
+		),
+	)...)
+}
+
+func buildOutputFormatMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := handler.ValidateOutputFormat(req); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
 func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
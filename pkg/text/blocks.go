@@ -0,0 +1,280 @@
+package text
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Block Kit blocks are rendered back to mrkdwn, so that their text goes
+// through ProcessText like the text of any other message: mentions become
+// <@U123>, styled text gets its *bold* markers and preformatted sections
+// become ``` blocks.
+
+var (
+	mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+	mdHeadingRe = regexp.MustCompile(`^#{1,6}\s+`)
+	mdBoldRe    = regexp.MustCompile(`\*\*([^*\n]+)\*\*|__([^_\n]+)__`)
+	mdItalicRe  = regexp.MustCompile(`\*([^*\s](?:[^*\n]*[^*\s])?)\*`)
+	mdStrikeRe  = regexp.MustCompile(`~~([^~\n]+)~~`)
+	mdLinkRe    = regexp.MustCompile(`\[([^\]\n]+)\]\(([^)\s]+)\)`)
+)
+
+// BlocksToText renders the text of the blocks of a message as mrkdwn, one
+// block per line. Blocks without text such as dividers or inputs are skipped
+func BlocksToText(blocks slack.Blocks) string {
+	var parts []string
+	for _, block := range blocks.BlockSet {
+		if s := strings.Trim(blockToText(block), "\n"); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func blockToText(block slack.Block) string {
+	switch b := block.(type) {
+	case *slack.RichTextBlock:
+		return richTextElementsToText(b.Elements)
+	case *slack.HeaderBlock:
+		if s := textObjectToText(b.Text); s != "" {
+			return "*" + s + "*"
+		}
+	case *slack.SectionBlock:
+		lines := []string{textObjectToText(b.Text)}
+		for _, field := range b.Fields {
+			lines = append(lines, textObjectToText(field))
+		}
+		if b.Accessory != nil {
+			lines = append(lines, buttonToText(b.Accessory.ButtonElement))
+		}
+		return joinNonEmpty(lines, "\n")
+	case *slack.ContextBlock:
+		var texts []string
+		for _, elem := range b.ContextElements.Elements {
+			if obj, ok := elem.(*slack.TextBlockObject); ok {
+				texts = append(texts, textObjectToText(obj))
+			}
+		}
+		return joinNonEmpty(texts, " ")
+	case *slack.ActionBlock:
+		if b.Elements == nil {
+			return ""
+		}
+		var buttons []string
+		for _, elem := range b.Elements.ElementSet {
+			if button, ok := elem.(*slack.ButtonBlockElement); ok {
+				buttons = append(buttons, buttonToText(button))
+			}
+		}
+		return joinNonEmpty(buttons, " | ")
+	case *slack.MarkdownBlock:
+		return markdownToMrkdwn(b.Text)
+	case *slack.ImageBlock:
+		return textObjectToText(b.Title)
+	case *slack.VideoBlock:
+		title := textObjectToText(b.Title)
+		if b.TitleURL != "" {
+			return "<" + b.TitleURL + "|" + title + ">"
+		}
+		return title
+	}
+	return ""
+}
+
+// markdownToMrkdwn converts the standard Markdown of markdown blocks: **bold**
+// becomes *bold*, *italic* _italic_, ~~strike~~ ~strike~, [text](url)
+// <url|text> and headings bold lines. Code is escaped only
+func markdownToMrkdwn(s string) string {
+	segments := splitCodeBlocks(s)
+	for i, segment := range segments {
+		// Odd segments are the contents of ``` blocks
+		if i%2 == 1 {
+			segments[i] = mrkdwnEscaper.Replace(segment)
+			continue
+		}
+		lines := strings.Split(segment, "\n")
+		for j, line := range lines {
+			lines[j] = markdownLineToMrkdwn(line)
+		}
+		segments[i] = strings.Join(lines, "\n")
+	}
+	return strings.Join(segments, codeFence)
+}
+
+func markdownLineToMrkdwn(line string) string {
+	heading := mdHeadingRe.MatchString(line)
+	line = mdHeadingRe.ReplaceAllString(line, "")
+
+	// Odd spans are inline code, unless the last backtick is unclosed
+	spans := strings.Split(line, "`")
+	for i, span := range spans {
+		span = mrkdwnEscaper.Replace(span)
+		if i%2 == 0 || i+1 == len(spans) {
+			span = mdBoldRe.ReplaceAllString(span, "\x00$1$2\x00")
+			span = mdItalicRe.ReplaceAllString(span, "_${1}_")
+			span = strings.ReplaceAll(span, "\x00", "*")
+			span = mdStrikeRe.ReplaceAllString(span, "~$1~")
+			span = mdLinkRe.ReplaceAllString(span, "<$2|$1>")
+		}
+		spans[i] = span
+	}
+	line = strings.Join(spans, "`")
+
+	if heading && line != "" {
+		return "*" + line + "*"
+	}
+	return line
+}
+
+// textObjectToText returns mrkdwn text as is and escapes plain text
+func textObjectToText(obj *slack.TextBlockObject) string {
+	if obj == nil {
+		return ""
+	}
+	if obj.Type == slack.MarkdownType {
+		return obj.Text
+	}
+	return mrkdwnEscaper.Replace(obj.Text)
+}
+
+// buttonToText renders link buttons, e.g. "View incident" of an alert, as
+// links. Buttons without a URL only trigger the app and are skipped
+func buttonToText(button *slack.ButtonBlockElement) string {
+	if button == nil || button.URL == "" {
+		return ""
+	}
+	if label := textObjectToText(button.Text); label != "" {
+		return "<" + button.URL + "|" + label + ">"
+	}
+	return "<" + button.URL + ">"
+}
+
+func richTextElementsToText(elements []slack.RichTextElement) string {
+	var lines []string
+	for _, elem := range elements {
+		var s string
+		switch e := elem.(type) {
+		case *slack.RichTextSection:
+			s = richTextSectionToText(e.Elements, true)
+		case *slack.RichTextList:
+			s = richTextListToText(e)
+		case *slack.RichTextQuote:
+			quoted := strings.Split(strings.TrimRight(richTextSectionToText(e.Elements, true), "\n"), "\n")
+			s = "&gt; " + strings.Join(quoted, "\n&gt; ")
+		case *slack.RichTextPreformatted:
+			s = codeFence + richTextSectionToText(e.Elements, false) + codeFence
+		}
+		lines = append(lines, strings.TrimRight(s, "\n"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// richTextListToText renders the items of a list, nested levels are separate
+// lists with a higher indent
+func richTextListToText(list *slack.RichTextList) string {
+	indent := strings.Repeat("    ", list.Indent)
+	var items []string
+	for i, elem := range list.Elements {
+		var item string
+		switch e := elem.(type) {
+		case *slack.RichTextSection:
+			item = richTextSectionToText(e.Elements, true)
+		case *slack.RichTextList:
+			items = append(items, richTextListToText(e))
+			continue
+		}
+
+		marker := "- "
+		if list.Style == slack.RTEListOrdered {
+			marker = strconv.Itoa(list.Offset+i+1) + ". "
+		}
+		items = append(items, indent+marker+strings.TrimRight(item, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+// richTextSectionToText renders the inline elements of a section, styles are
+// left out in preformatted sections
+func richTextSectionToText(elements []slack.RichTextSectionElement, styled bool) string {
+	var b strings.Builder
+	for _, elem := range elements {
+		switch e := elem.(type) {
+		case *slack.RichTextSectionTextElement:
+			s := mrkdwnEscaper.Replace(e.Text)
+			if styled {
+				s = styleText(s, e.Style)
+			}
+			b.WriteString(s)
+		case *slack.RichTextSectionLinkElement:
+			if e.Text != "" {
+				b.WriteString("<" + e.URL + "|" + mrkdwnEscaper.Replace(e.Text) + ">")
+			} else {
+				b.WriteString("<" + e.URL + ">")
+			}
+		case *slack.RichTextSectionUserElement:
+			b.WriteString("<@" + e.UserID + ">")
+		case *slack.RichTextSectionChannelElement:
+			b.WriteString("<#" + e.ChannelID + ">")
+		case *slack.RichTextSectionUserGroupElement:
+			b.WriteString("<!subteam^" + e.UsergroupID + ">")
+		case *slack.RichTextSectionBroadcastElement:
+			b.WriteString("<!" + e.Range + ">")
+		case *slack.RichTextSectionDateElement:
+			date := "<!date^" + strconv.FormatInt(int64(e.Timestamp), 10) + "^" + e.Format
+			if e.URL != nil {
+				date += "^" + *e.URL
+			}
+			if e.Fallback != nil {
+				date += "|" + mrkdwnEscaper.Replace(*e.Fallback)
+			}
+			b.WriteString(date + ">")
+		case *slack.RichTextSectionEmojiElement:
+			b.WriteString(":" + e.Name + ":")
+		case *slack.RichTextSectionColorElement:
+			b.WriteString(e.Value)
+		}
+	}
+	return b.String()
+}
+
+// styleText wraps text in mrkdwn markers, keeping surrounding spaces outside
+// of them since Slack does not close a marker after a space
+func styleText(s string, style *slack.RichTextSectionTextStyle) string {
+	if style == nil {
+		return s
+	}
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	start := strings.Index(s, trimmed)
+	leading, trailing := s[:start], s[start+len(trimmed):]
+
+	if style.Code {
+		trimmed = "`" + trimmed + "`"
+	}
+	if style.Strike {
+		trimmed = "~" + trimmed + "~"
+	}
+	if style.Italic {
+		trimmed = "_" + trimmed + "_"
+	}
+	if style.Bold {
+		trimmed = "*" + trimmed + "*"
+	}
+	return leading + trimmed + trailing
+}
+
+func joinNonEmpty(parts []string, sep string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
@@ -0,0 +1,88 @@
+package text
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestBlocksToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   string
+		expected string
+	}{
+		{
+			name: "rich text",
+			blocks: `[{"type": "rich_text", "elements": [
+				{"type": "rich_text_section", "elements": [
+					{"type": "text", "text": "Deploy "},
+					{"type": "text", "text": "failed ", "style": {"bold": true}},
+					{"type": "text", "text": "for a < b", "style": {"code": true}},
+					{"type": "text", "text": ", cc "},
+					{"type": "user", "user_id": "U123"},
+					{"type": "text", "text": " in "},
+					{"type": "channel", "channel_id": "C123"},
+					{"type": "text", "text": " "},
+					{"type": "broadcast", "range": "here"},
+					{"type": "text", "text": " "},
+					{"type": "emoji", "name": "fire"},
+					{"type": "text", "text": "\n"}
+				]},
+				{"type": "rich_text_list", "style": "ordered", "indent": 0, "elements": [
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "roll back"}]},
+					{"type": "rich_text_section", "elements": [{"type": "link", "url": "https://example.com/runbook", "text": "read runbook"}]}
+				]},
+				{"type": "rich_text_list", "style": "bullet", "indent": 1, "elements": [
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "nested"}]}
+				]},
+				{"type": "rich_text_quote", "elements": [{"type": "text", "text": "quoted\nlines"}]},
+				{"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "panic: *boom*"}]}
+			]}]`,
+			expected: "Deploy **failed** `for a < b`, cc @U123 in #C123 @here :fire:\n" +
+				"1. roll back\n2. [read runbook](https://example.com/runbook)\n    - nested\n" +
+				"> quoted\n> lines\n```\npanic: *boom*\n```",
+		},
+		{
+			name: "alert",
+			blocks: `[
+				{"type": "header", "text": {"type": "plain_text", "text": "Incident #42 triggered"}},
+				{"type": "section", "text": {"type": "mrkdwn", "text": "*Service:* payments-api"},
+					"fields": [{"type": "mrkdwn", "text": "*Urgency:* high"}, {"type": "plain_text", "text": "Assigned: <nobody>"}],
+					"accessory": {"type": "button", "text": {"type": "plain_text", "text": "View incident"}, "url": "https://example.pagerduty.com/incidents/42"}},
+				{"type": "divider"},
+				{"type": "context", "elements": [{"type": "image", "image_url": "https://example.com/a.png", "alt_text": "logo"}, {"type": "mrkdwn", "text": "Triggered by Datadog"}]},
+				{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Acknowledge"}, "action_id": "ack"}]}
+			]`,
+			expected: "**Incident #42 triggered**\n**Service:** payments-api\n**Urgency:** high\nAssigned: <nobody>\n" +
+				"[View incident](https://example.pagerduty.com/incidents/42)\nTriggered by Datadog",
+		},
+		{
+			name: "markdown",
+			blocks: `[
+				{"type": "markdown", "text": "## Release notes\n**Build** passed for *a < b*, see [logs](https://ci.example.com/1) and ~~old~~ ` + "`*raw*`" + `\n- fixed **bugs**\n` + "```\\ngo test *\\n```" + `"},
+				{"type": "image", "image_url": "https://example.com/chart.png", "alt_text": "chart", "title": {"type": "plain_text", "text": "Latency p99"}},
+				{"type": "image", "image_url": "https://example.com/logo.png", "alt_text": "logo"}
+			]`,
+			expected: "**Release notes**\n**Build** passed for _a < b_, see [logs](https://ci.example.com/1) and ~~old~~ `*raw*`\n- fixed **bugs**\n" +
+				"```\ngo test *\n```\nLatency p99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blocks slack.Blocks
+			if err := json.Unmarshal([]byte(tt.blocks), &blocks); err != nil {
+				t.Fatalf("failed to unmarshal blocks: %v", err)
+			}
+			if result := RenderMarkdown(BlocksToText(blocks), nil); result != tt.expected {
+				t.Errorf("BlocksToText() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+
+	if result := BlocksToText(slack.Blocks{}); result != "" {
+		t.Errorf("BlocksToText() = %q, expected empty text", result)
+	}
+}
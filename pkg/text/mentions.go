@@ -0,0 +1,121 @@
+package text
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MentionResolver looks up the names of users, channels and user groups
+// mentioned in message text, ok is false for unknown IDs. Names are returned
+// without their @ or # prefix
+type MentionResolver interface {
+	UserName(id string) (name string, ok bool)
+	ChannelName(id string) (name string, ok bool)
+	UsergroupHandle(id string) (handle string, ok bool)
+}
+
+// specialMentions are the <!...> mentions notifying a whole channel
+var specialMentions = map[string]bool{
+	"here":     true,
+	"channel":  true,
+	"everyone": true,
+}
+
+// renderMention renders a user, channel, user group or special mention or a
+// date, the label Slack sent along is used for IDs the resolver does not know
+func renderMention(target, label string, mentions MentionResolver) (string, bool) {
+	var (
+		prefix string
+		id     string
+		lookup func(string) (string, bool)
+	)
+	switch {
+	case strings.HasPrefix(target, "@"):
+		prefix, id = "@", target[1:]
+		if mentions != nil {
+			lookup = mentions.UserName
+		}
+	case strings.HasPrefix(target, "#"):
+		prefix, id = "#", target[1:]
+		if mentions != nil {
+			lookup = mentions.ChannelName
+		}
+	case strings.HasPrefix(target, "!subteam^"):
+		prefix, id = "@", strings.TrimPrefix(target, "!subteam^")
+		if mentions != nil {
+			lookup = mentions.UsergroupHandle
+		}
+	case strings.HasPrefix(target, "!date^"):
+		if date, ok := formatSlackDate(target); ok {
+			return date, true
+		}
+		if label != "" {
+			return label, true
+		}
+		return target, true
+	case strings.HasPrefix(target, "!"):
+		name, _, _ := strings.Cut(target[1:], "^")
+		if specialMentions[name] {
+			return "@" + name, true
+		}
+		if label != "" {
+			return label, true
+		}
+		return "@" + name, true
+	default:
+		return "", false
+	}
+
+	if lookup != nil {
+		if name, ok := lookup(id); ok && name != "" {
+			return prefix + strings.TrimPrefix(name, prefix), true
+		}
+	}
+	if label != "" {
+		return prefix + strings.TrimPrefix(label, prefix), true
+	}
+	return prefix + id, true
+}
+
+// formatSlackDate renders <!date^1392734382^{date_short} at {time}^link> in
+// UTC, see https://api.slack.com/reference/surfaces/formatting#date-formatting
+func formatSlackDate(target string) (string, bool) {
+	parts := strings.Split(target, "^")
+	if len(parts) < 3 {
+		return "", false
+	}
+	sec, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", false
+	}
+	t := time.Unix(sec, 0).UTC()
+
+	date := ordinalDate(t)
+	return strings.NewReplacer(
+		"{date_num}", t.Format("2006-01-02"),
+		"{date_short_pretty}", t.Format("Jan 2, 2006"),
+		"{date_long_pretty}", t.Format("Monday, ")+date,
+		"{date_pretty}", date,
+		"{date_short}", t.Format("Jan 2, 2006"),
+		"{date_long}", t.Format("Monday, ")+date,
+		"{date}", date,
+		"{time_secs}", t.Format("15:04:05 UTC"),
+		"{time}", t.Format("15:04 UTC"),
+		"{ago}", t.Format("Jan 2, 2006 15:04 UTC"),
+	).Replace(parts[2]), true
+}
+
+// ordinalDate formats t like Slack's {date} token, e.g. February 18th, 2014
+func ordinalDate(t time.Time) string {
+	suffix := "th"
+	switch day := t.Day(); {
+	case day == 1 || day == 21 || day == 31:
+		suffix = "st"
+	case day == 2 || day == 22:
+		suffix = "nd"
+	case day == 3 || day == 23:
+		suffix = "rd"
+	}
+	return t.Format("January 2") + suffix + t.Format(", 2006")
+}
@@ -0,0 +1,58 @@
+package text
+
+import "testing"
+
+type mapResolver map[string]string
+
+func (m mapResolver) UserName(id string) (string, bool)        { name, ok := m[id]; return name, ok }
+func (m mapResolver) ChannelName(id string) (string, bool)     { name, ok := m[id]; return name, ok }
+func (m mapResolver) UsergroupHandle(id string) (string, bool) { name, ok := m[id]; return name, ok }
+
+func TestRenderMentions(t *testing.T) {
+	mentions := mapResolver{"U123": "alice", "C123": "payments", "S123": "oncall"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "resolved",
+			input:    "<@U123> in <#C123|> cc <!subteam^S123|@old>",
+			expected: "@alice in #payments cc @oncall",
+		},
+		{
+			name:     "unknown IDs keep the label",
+			input:    "<@U999|bob> <@U998> <#C999|general>",
+			expected: "@bob @U998 #general",
+		},
+		{
+			name:     "special mentions",
+			input:    "<!here|here> <!channel> <!everyone>",
+			expected: "@here @channel @everyone",
+		},
+		{
+			name:     "date tokens",
+			input:    "<!date^1392734382^{date_num} {time_secs}|fallback>, <!date^1392734382^{date_long} at {time}|fallback>",
+			expected: "2014-02-18 14:39:42 UTC, Tuesday, February 18th, 2014 at 14:39 UTC",
+		},
+		{
+			name:     "invalid date uses the fallback",
+			input:    "<!date^soon^{date}|tomorrow>",
+			expected: "tomorrow",
+		},
+		{
+			name:     "mentions in code",
+			input:    "`<@U123>`",
+			expected: "`@alice`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RenderMarkdown(tt.input, mentions); result != tt.expected {
+				t.Errorf("RenderMarkdown() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package text
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Slack mrkdwn (https://api.slack.com/reference/surfaces/formatting) is
+// rendered to Markdown which models read natively. Code is kept verbatim,
+// links become [text](url) and *bold* / ~strike~ get their Markdown markers.
+// The compact mode drops emphasis and blank lines to save tokens.
+
+var (
+	bulletRe     = regexp.MustCompile(`^(\s*)[•◦▪▫‣⁃]\s*`)
+	spaceRe      = regexp.MustCompile(`[ \t]+`)
+	entityRe     = regexp.MustCompile(`<([^<>\n]+)>`)
+	placeholdRe  = regexp.MustCompile("\x00([0-9]+)\x00")
+	htmlEscaper  = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	labelEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+)
+
+const codeFence = "```"
+
+// ProcessText renders the mrkdwn of a message as Markdown, or as compact
+// text when SLACK_MCP_COMPACT_TEXT is enabled. Mentions are resolved to
+// names with mentions, which may be nil
+func ProcessText(s string, mentions MentionResolver) string {
+	switch strings.ToLower(os.Getenv("SLACK_MCP_COMPACT_TEXT")) {
+	case "1", "true", "yes":
+		return RenderCompact(s, mentions)
+	default:
+		return RenderMarkdown(s, mentions)
+	}
+}
+
+// RenderMarkdown renders Slack mrkdwn as Markdown
+func RenderMarkdown(s string, mentions MentionResolver) string {
+	return renderMrkdwn(s, false, mentions)
+}
+
+// RenderCompact renders Slack mrkdwn as plain text without emphasis markers
+// and blank lines, code is kept as is
+func RenderCompact(s string, mentions MentionResolver) string {
+	return renderMrkdwn(s, true, mentions)
+}
+
+func renderMrkdwn(s string, compact bool, mentions MentionResolver) string {
+	var out []string
+	for i, segment := range splitCodeBlocks(s) {
+		// Odd segments are the contents of ``` blocks
+		if i%2 == 1 {
+			code := strings.Trim(renderCode(segment, mentions), "\n")
+			out = append(out, codeFence+"\n"+code+"\n"+codeFence)
+			continue
+		}
+		// Text after a block continues on a new line
+		if i > 0 {
+			segment = strings.TrimPrefix(strings.TrimLeft(segment, " \t"), "\n")
+		}
+		if rendered := renderBlocks(segment, compact, mentions); rendered != "" {
+			out = append(out, rendered)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// splitCodeBlocks splits s into text and code block contents, alternating
+// and starting with text. An unclosed fence is kept as text
+func splitCodeBlocks(s string) []string {
+	var segments []string
+	for {
+		start := strings.Index(s, codeFence)
+		if start == -1 {
+			break
+		}
+		end := strings.Index(s[start+len(codeFence):], codeFence)
+		if end == -1 {
+			break
+		}
+		end += start + len(codeFence)
+		segments = append(segments, s[:start], s[start+len(codeFence):end])
+		s = s[end+len(codeFence):]
+	}
+	return append(segments, s)
+}
+
+// renderBlocks renders quotes and bullet lists line by line and the inline
+// formatting of each line
+func renderBlocks(s string, compact bool, mentions MentionResolver) string {
+	var (
+		lines      []string
+		quoteAll   bool
+		prevBlank  bool
+		hasContent bool
+	)
+	for _, line := range strings.Split(s, "\n") {
+		quote := quoteAll
+		switch {
+		case strings.HasPrefix(line, "&gt;&gt;&gt;"):
+			quote, quoteAll = true, true
+			line = strings.TrimPrefix(line, "&gt;&gt;&gt;")
+		case strings.HasPrefix(line, ">>>"):
+			quote, quoteAll = true, true
+			line = strings.TrimPrefix(line, ">>>")
+		case strings.HasPrefix(line, "&gt;"):
+			quote = true
+			line = strings.TrimPrefix(line, "&gt;")
+		case strings.HasPrefix(line, ">"):
+			quote = true
+			line = strings.TrimPrefix(line, ">")
+		}
+		if quote {
+			line = strings.TrimPrefix(line, " ")
+		}
+
+		line = bulletRe.ReplaceAllString(line, "$1- ")
+		line = strings.TrimRight(renderInline(line, compact, mentions), " \t")
+		if compact {
+			line = spaceRe.ReplaceAllString(strings.TrimSpace(line), " ")
+		}
+
+		blank := strings.TrimSpace(line) == ""
+		if blank && (compact || prevBlank || !hasContent) {
+			continue
+		}
+		prevBlank, hasContent = blank, hasContent || !blank
+
+		if quote {
+			line = strings.TrimRight("> "+line, " ")
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// renderInline renders links, mentions, inline code and emphasis of a line.
+// Entities and code spans are swapped for placeholders first so that
+// underscores in URLs or code are not taken for emphasis
+func renderInline(s string, compact bool, mentions MentionResolver) string {
+	var saved []string
+	save := func(rendered string) string {
+		saved = append(saved, rendered)
+		return "\x00" + strconv.Itoa(len(saved)-1) + "\x00"
+	}
+
+	s = replaceInlineCode(s, func(code string) string {
+		return save("`" + renderCode(code, mentions) + "`")
+	})
+	s = entityRe.ReplaceAllStringFunc(s, func(m string) string {
+		return save(renderEntity(m[1:len(m)-1], compact, mentions))
+	})
+
+	bold, strike := "**", "~~"
+	italic := "_"
+	if compact {
+		bold, strike, italic = "", "", ""
+	}
+	s = replaceEmphasis(s, '*', bold)
+	s = replaceEmphasis(s, '_', italic)
+	s = replaceEmphasis(s, '~', strike)
+	s = htmlEscaper.Replace(s)
+
+	return placeholdRe.ReplaceAllStringFunc(s, func(m string) string {
+		i, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+		return saved[i]
+	})
+}
+
+// replaceInlineCode replaces `code` spans of a line with the result of fn
+func replaceInlineCode(s string, fn func(code string) string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '`')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '`')
+		if end <= 0 {
+			break
+		}
+		end += start + 1
+		b.WriteString(s[:start])
+		b.WriteString(fn(s[start+1 : end]))
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// replaceEmphasis swaps the marker pairs around words, e.g. *bold*, for
+// markdown. Like Slack, a marker only opens after a non-word character and
+// before a non-space, and the pair must not span lines
+func replaceEmphasis(s string, marker byte, markdown string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != marker || (i > 0 && isWordByte(s[i-1])) || i+1 >= len(s) || isSpaceByte(s[i+1]) || s[i+1] == marker {
+			b.WriteByte(s[i])
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(s) && s[j] != '\n'; j++ {
+			if s[j] == marker && !isSpaceByte(s[j-1]) && (j+1 == len(s) || !isWordByte(s[j+1])) {
+				end = j
+				break
+			}
+		}
+		if end == -1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		b.WriteString(markdown)
+		b.WriteString(s[i+1 : end])
+		b.WriteString(markdown)
+		i = end
+	}
+	return b.String()
+}
+
+// renderEntity renders the content of a <...> entity: links, user, channel
+// and user group mentions, special mentions and dates
+func renderEntity(entity string, compact bool, mentions MentionResolver) string {
+	target, label, hasLabel := strings.Cut(entity, "|")
+	label = htmlEscaper.Replace(label)
+
+	if mention, ok := renderMention(target, label, mentions); ok {
+		return mention
+	}
+
+	url := htmlEscaper.Replace(target)
+	text := strings.TrimPrefix(url, "mailto:")
+	if !hasLabel || label == "" || label == url || label == text {
+		return text
+	}
+	if compact {
+		return label + " (" + url + ")"
+	}
+	return "[" + labelEscaper.Replace(label) + "](" + url + ")"
+}
+
+// renderCode unescapes code and shows links in it as their plain text
+func renderCode(code string, mentions MentionResolver) string {
+	code = entityRe.ReplaceAllStringFunc(code, func(m string) string {
+		target, label, hasLabel := strings.Cut(m[1:len(m)-1], "|")
+		if strings.HasPrefix(target, "@") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "!") {
+			return renderEntity(m[1:len(m)-1], true, mentions)
+		}
+		if hasLabel && label != "" {
+			return label
+		}
+		return target
+	})
+	return htmlEscaper.Replace(code)
+}
+
+func isWordByte(c byte) bool {
+	return c >= 0x80 || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
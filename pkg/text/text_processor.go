@@ -153,30 +153,31 @@ func Workspace(rawURL string) (string, error) {
 }
 
 func TimestampToIsoRFC3339(slackTS string) (string, error) {
+	t, err := TimestampToTime(slackTS)
+	if err != nil {
+		return "", err
+	}
+
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+func TimestampToTime(slackTS string) (time.Time, error) {
 	parts := strings.Split(slackTS, ".")
 	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid slack timestamp format: %s", slackTS)
+		return time.Time{}, fmt.Errorf("invalid slack timestamp format: %s", slackTS)
 	}
 
 	seconds, err := strconv.ParseInt(parts[0], 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse seconds: %v", err)
+		return time.Time{}, fmt.Errorf("failed to parse seconds: %v", err)
 	}
 
 	microseconds, err := strconv.ParseInt(parts[1], 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse microseconds: %v", err)
+		return time.Time{}, fmt.Errorf("failed to parse microseconds: %v", err)
 	}
 
-	t := time.Unix(seconds, microseconds*1000)
-
-	return t.UTC().Format(time.RFC3339), nil
-}
-
-func ProcessText(s string) string {
-	s = filterSpecialChars(s)
-
-	return s
+	return time.Unix(seconds, microseconds*1000), nil
 }
 
 func HumanizeCertificates(certs []*x509.Certificate) string {
@@ -191,92 +192,3 @@ func HumanizeCertificates(certs []*x509.Certificate) string {
 	}
 	return strings.Join(descriptions, ", ")
 }
-
-func filterSpecialChars(text string) string {
-	replaceWithCommaCheck := func(match []string, isLast bool) string {
-		var url, linkText string
-
-		if len(match) == 3 && strings.Contains(match[0], "|") {
-			url = match[1]
-			linkText = match[2]
-		} else if len(match) == 3 {
-			linkText = match[1]
-			url = match[2]
-		}
-
-		replacement := url + " - " + linkText
-
-		if !isLast {
-			replacement += ","
-		}
-
-		return replacement
-	}
-
-	// Helper function to check if this is the last link/element
-	isLastInText := func(original string, currentText string) bool {
-		linkPos := strings.LastIndex(currentText, original)
-		if linkPos == -1 {
-			return false
-		}
-		afterLink := strings.TrimSpace(currentText[linkPos+len(original):])
-		return afterLink == ""
-	}
-
-	// Handle Slack-style links: <URL|Description>
-	slackLinkRegex := regexp.MustCompile(`<(https?://[^>|]+)\|([^>]+)>`)
-	slackMatches := slackLinkRegex.FindAllStringSubmatch(text, -1)
-	for _, match := range slackMatches {
-		original := match[0]
-		isLast := isLastInText(original, text)
-		replacement := replaceWithCommaCheck(match, isLast)
-		text = strings.Replace(text, original, replacement, 1)
-	}
-
-	// Handle markdown links: [Description](URL)
-	markdownLinkRegex := regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)]+)\)`)
-	markdownMatches := markdownLinkRegex.FindAllStringSubmatch(text, -1)
-	for _, match := range markdownMatches {
-		original := match[0]
-		isLast := isLastInText(original, text)
-		replacement := replaceWithCommaCheck(match, isLast)
-		text = strings.Replace(text, original, replacement, 1)
-	}
-
-	htmlLinkRegex := regexp.MustCompile(`<a\s+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
-	htmlMatches := htmlLinkRegex.FindAllStringSubmatch(text, -1)
-	for _, match := range htmlMatches {
-		original := match[0]
-		isLast := isLastInText(original, text)
-		url := match[1]
-		linkText := match[2]
-		replacement := url + " - " + linkText
-		if !isLast {
-			replacement += ","
-		}
-		text = strings.Replace(text, original, replacement, 1)
-	}
-
-	urlRegex := regexp.MustCompile(`https?://[^\s<>"{}|\\^` + "`" + `\[\]]+`)
-	urls := urlRegex.FindAllString(text, -1)
-
-	protected := text
-	for i, url := range urls {
-		placeholder := "___URL_PLACEHOLDER_" + string(rune(48+i)) + "___"
-		protected = strings.Replace(protected, url, placeholder, 1)
-	}
-
-	cleanRegex := regexp.MustCompile(`[^0-9\p{L}\p{M}\s\.\,\-_:/\?=&%]`)
-	cleaned := cleanRegex.ReplaceAllString(protected, "")
-
-	// Restore the URLs
-	for i, url := range urls {
-		placeholder := "___URL_PLACEHOLDER_" + string(rune(48+i)) + "___"
-		cleaned = strings.Replace(cleaned, placeholder, url, 1)
-	}
-
-	spaceRegex := regexp.MustCompile(`[ \t]+`)
-	cleaned = spaceRegex.ReplaceAllString(cleaned, " ")
-
-	return strings.TrimSpace(cleaned)
-}
@@ -108,55 +108,84 @@ func TestIsUnfurlingEnabled(t *testing.T) {
 	}
 }
 
-func TestFilterSpecialCharsWithCommas(t *testing.T) {
+func TestRenderMarkdown(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
 		expected string
 	}{
 		{
-			name:     "Slack-style link in middle",
-			input:    "aaabbcc <https://google.com|This is a link> aabbcc",
-			expected: "aaabbcc https://google.com - This is a link, aabbcc",
+			name:     "emphasis",
+			input:    "*bold* _italic_ ~strike~ and snake_case_name, 2*3*4",
+			expected: "**bold** _italic_ ~~strike~~ and snake_case_name, 2*3*4",
 		},
 		{
-			name:     "Slack-style link at end",
-			input:    "aaabbcc <https://google.com|This is a link>",
-			expected: "aaabbcc https://google.com - This is a link",
+			name:     "links",
+			input:    "See <https://example.com/a_b_c|the *docs*> or <https://example.com> or <mailto:bob@example.com|bob@example.com>",
+			expected: "See [the *docs*](https://example.com/a_b_c) or https://example.com or bob@example.com",
 		},
 		{
-			name:     "Slack-style link at end with spaces",
-			input:    "aaabbcc <https://google.com|This is a link>   ",
-			expected: "aaabbcc https://google.com - This is a link",
+			name:     "mentions",
+			input:    "<@U123|alice> <@U456> in <#C123|general>, <!here> <!subteam^S123|@oncall> <!date^1392734382^{date_short}|Feb 18, 2014>",
+			expected: "@alice @U456 in #general, @here @oncall Feb 18, 2014",
 		},
 		{
-			name:     "Two links, second at end",
-			input:    "First <https://site1.com|Site One> then <https://site2.com|Site Two>",
-			expected: "First https://site1.com - Site One, then https://site2.com - Site Two",
+			name:     "escapes and emoji",
+			input:    "a &lt; b &amp;&amp; c &gt; d :tada: — «ok» 👍",
+			expected: "a < b && c > d :tada: — «ok» 👍",
 		},
 		{
-			name:     "Two links, text after second",
-			input:    "First <https://site1.com|Site One> then <https://site2.com|Site Two> done",
-			expected: "First https://site1.com - Site One, then https://site2.com - Site Two, done",
+			name:     "code block",
+			input:    "Stack trace: ```panic: boom\n\tat main.go:12 *not bold* &lt;nil&gt;``` thanks",
+			expected: "Stack trace:\n```\npanic: boom\n\tat main.go:12 *not bold* <nil>\n```\nthanks",
 		},
 		{
-			name:     "Markdown link at end",
-			input:    "Check this [Google](https://google.com)",
-			expected: "Check this https://google.com - Google",
+			name:     "inline code",
+			input:    "run `go test ./..._test` now",
+			expected: "run `go test ./..._test` now",
 		},
 		{
-			name:     "Markdown link in middle",
-			input:    "Check this [Google](https://google.com) out",
-			expected: "Check this https://google.com - Google, out",
+			name:     "quotes and lists",
+			input:    "&gt; quoted *line*\nplain\n• one\n    ◦ nested\n1. first",
+			expected: "> quoted **line**\nplain\n- one\n    - nested\n1. first",
+		},
+		{
+			name:     "multi-line quote",
+			input:    "&gt;&gt;&gt;first\nsecond",
+			expected: "> first\n> second",
+		},
+		{
+			name:     "unclosed fence",
+			input:    "``` not code",
+			expected: "``` not code",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := filterSpecialChars(tt.input)
+			result := RenderMarkdown(tt.input, nil)
 			if result != tt.expected {
-				t.Errorf("filterSpecialChars() = %q, expected %q", result, tt.expected)
+				t.Errorf("RenderMarkdown() = %q, expected %q", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestRenderCompact(t *testing.T) {
+	input := "*Deploy*   done\n\n\nsee <https://example.com|runbook>\n```a  b\n\nc```"
+	expected := "Deploy done\nsee runbook (https://example.com)\n```\na  b\n\nc\n```"
+	if result := RenderCompact(input, nil); result != expected {
+		t.Errorf("RenderCompact() = %q, expected %q", result, expected)
+	}
+}
+
+func TestProcessText(t *testing.T) {
+	t.Setenv("SLACK_MCP_COMPACT_TEXT", "")
+	if result := ProcessText("*hi*", nil); result != "**hi**" {
+		t.Errorf("ProcessText() = %q, expected %q", result, "**hi**")
+	}
+	t.Setenv("SLACK_MCP_COMPACT_TEXT", "true")
+	if result := ProcessText("*hi*", nil); result != "hi" {
+		t.Errorf("ProcessText() = %q, expected %q", result, "hi")
+	}
+}